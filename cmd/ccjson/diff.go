@@ -0,0 +1,75 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+
+	"github.com/mdwhatcott/coding-challenges.fyi-json/lib/diffing"
+	"github.com/mdwhatcott/coding-challenges.fyi-json/lib/parsing"
+	"github.com/mdwhatcott/coding-challenges.fyi-json/lib/patching"
+	"github.com/mdwhatcott/coding-challenges.fyi-json/lib/printing"
+)
+
+func diffCommand(program string, args []string) {
+	var format string
+	var color string
+	var options diffing.Options
+	flags := flag.NewFlagSet(fmt.Sprintf("%s diff @ %s", program, Version), flag.ExitOnError)
+	flags.StringVar(&format, "format", "text", "How to report differences, one of 'text', 'json', 'patch' (RFC 6902), or 'colors' (short for -format text -color always).")
+	flags.StringVar(&color, "color", "auto", "When to color text output, one of 'auto' (when stdout is a terminal; honors NO_COLOR and FORCE_COLOR), 'always', 'never'.")
+	flags.BoolVar(&options.IgnoreKeyOrder, "ignore-key-order", false, "Don't report objects whose members are merely in a different order.")
+	flags.BoolVar(&options.IgnoreArrayOrder, "ignore-array-order", false, "Compare arrays as unordered collections of elements.")
+	flags.Usage = func() {
+		_, _ = fmt.Fprintln(flags.Output(), flags.Name())
+		_, _ = fmt.Fprintln(flags.Output(), "> Compares two JSON documents semantically, reports added (+), removed (-) and changed (~) values by JSON Pointer.")
+		_, _ = fmt.Fprintln(flags.Output(), "> Exits with status 1 when the documents differ.")
+		_, _ = fmt.Fprintln(flags.Output(), "> Example usage:")
+		_, _ = fmt.Fprintf(flags.Output(), "$ %s diff -format text a.json b.json\n", program)
+		_, _ = fmt.Fprintln(flags.Output(), `~ /baz/2: 3 -> 4`)
+		_, _ = fmt.Fprintln(flags.Output(), "> Flags:")
+		flags.PrintDefaults()
+	}
+	_ = flags.Parse(args)
+	if flags.NArg() != 2 {
+		flags.Usage()
+		os.Exit(2)
+	}
+	if format != "colors" && format != "text" && format != "json" && format != "patch" {
+		log.Fatalln("Invalid diff format:", format)
+	}
+	validateColorMode(color)
+	if format == "colors" {
+		format, color = "text", "always"
+	}
+	a := parseFile(flags.Arg(0))
+	b := parseFile(flags.Arg(1))
+	if !diffJSON(os.Stdout, a, b, format, colorEnabled(color, os.Stdout), options) {
+		os.Exit(1)
+	}
+}
+func diffJSON(output io.Writer, a, b any, format string, colored bool, options diffing.Options) (same bool) {
+	if format == "patch" {
+		operations := patching.Generate(a, b)
+		parsing.Render(patching.Value(operations), printing.NewIndentingPrinter(output))
+		_, _ = fmt.Fprintln(output)
+		log.Printf("Generated %d patch operation(s).", len(operations))
+		return len(operations) == 0
+	}
+	changes := diffing.Compare(a, b, options)
+	switch format {
+	case "json":
+		parsing.Render(diffing.Value(changes), printing.NewIndentingPrinter(output))
+		_, _ = fmt.Fprintln(output)
+	default:
+		diffing.WriteText(output, changes, colored)
+	}
+	if len(changes) > 0 {
+		log.Printf("Documents differ in %d place(s).", len(changes))
+		return false
+	}
+	log.Println("Documents are equivalent.")
+	return true
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+
+	"github.com/mdwhatcott/coding-challenges.fyi-json/lib/flattening"
+	"github.com/mdwhatcott/coding-challenges.fyi-json/lib/parsing"
+)
+
+func flattenCommand(program string, args []string, unflatten bool) {
+	var separator string
+	name, description := "flatten", "Flattens nested arrays and objects into a single-level object keyed by paths such as 'a.b.0.c'"
+	if unflatten {
+		name, description = "unflatten", "Rebuilds nested arrays and objects from a single-level object keyed by paths such as 'a.b.0.c'"
+	}
+	flags := flag.NewFlagSet(fmt.Sprintf("%s %s @ %s", program, name, Version), flag.ExitOnError)
+	output := outputFlags(flags, "How to format the output")
+	flags.StringVar(&separator, "separator", ".", "Separator between the keys of a path (must not contain a backslash).")
+	flags.Usage = func() {
+		_, _ = fmt.Fprintln(flags.Output(), flags.Name())
+		_, _ = fmt.Fprintf(flags.Output(), "> %s, reading a file (or stdin), outputs JSON to stdout.\n", description)
+		_, _ = fmt.Fprintln(flags.Output(), "> The characters of the separator (and backslashes) within keys are escaped with a backslash: {\"a.b\": {\"c\": 1}} <-> {\"a\\\\.b.c\": 1}.")
+		if unflatten {
+			_, _ = fmt.Fprintln(flags.Output(), "> Objects whose keys are exactly 0 through n-1 become arrays.")
+		}
+		_, _ = fmt.Fprintln(flags.Output(), "> Example usage:")
+		example := exampleInput
+		if unflatten {
+			example = `{"foo":"bar","baz.0":1,"baz.1":2,"baz.2":3}`
+		}
+		_, _ = fmt.Fprintf(flags.Output(), "$ echo -n '%s' | %s %s -fmt compact\n", example, program, name)
+		document, _ := parsing.ParseBytes([]byte(example))
+		flattenJSON(flags.Output(), document, outputOptions{format: "compact", color: "never"}, ".", unflatten)
+		_, _ = fmt.Fprintln(flags.Output(), "> Flags:")
+		flags.PrintDefaults()
+	}
+	_ = flags.Parse(args)
+	if flags.NArg() > 1 {
+		flags.Usage()
+		os.Exit(2)
+	}
+	output.validate()
+	var document any
+	if flags.NArg() == 0 {
+		var err error
+		document, err = parsing.Parse(os.Stdin)
+		if err != nil {
+			log.Fatalln(err)
+		}
+	} else {
+		document = parseFile(flags.Arg(0))
+	}
+	flattenJSON(os.Stdout, document, *output, separator, unflatten)
+}
+func flattenJSON(output io.Writer, document any, options outputOptions, separator string, unflatten bool) {
+	var result any
+	var err error
+	if unflatten {
+		result, err = flattening.Unflatten(document, separator)
+	} else {
+		result, err = flattening.Flatten(document, separator)
+	}
+	if err != nil {
+		log.Fatalln(err)
+	}
+	parsing.Render(result, options.newPrinter(output))
+	options.endLine(output)
+}
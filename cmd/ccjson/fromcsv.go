@@ -0,0 +1,113 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/mdwhatcott/coding-challenges.fyi-json/lib/lexing"
+	"github.com/mdwhatcott/coding-challenges.fyi-json/lib/parsing"
+	"github.com/mdwhatcott/coding-challenges.fyi-json/lib/tabular"
+)
+
+const exampleCSV = "name,age,admin\nbob,42,true\n"
+
+func fromCSVCommand(program string, args []string) {
+	var tsv, ndjson bool
+	var infer string
+	flags := flag.NewFlagSet(fmt.Sprintf("%s from-csv @ %s", program, Version), flag.ExitOnError)
+	output := outputFlags(flags, "How to format the output")
+	flags.BoolVar(&tsv, "tsv", false, "Read tab-separated values rather than comma-separated values.")
+	flags.StringVar(&infer, "infer", "none", "Which cells to convert from strings, 'all', 'none' or any of 'numbers' (JSON numbers only, so '007' stays a string), 'booleans' ('true', 'false'), 'nulls' (empty cells), separated by commas.")
+	flags.BoolVar(&ndjson, "ndjson", false, "Output one compact object per line (NDJSON) rather than an array (ignores -fmt).")
+	flags.Usage = func() {
+		_, _ = fmt.Fprintln(flags.Output(), flags.Name())
+		_, _ = fmt.Fprintln(flags.Output(), "> Converts CSV (or TSV) with a header row from a file (or stdin) into an array of objects, one per row, output to stdout.")
+		_, _ = fmt.Fprintln(flags.Output(), "> Example usage:")
+		_, _ = fmt.Fprintf(flags.Output(), "$ printf '%s' | %s from-csv -infer all -fmt compact\n", strings.ReplaceAll(exampleCSV, "\n", `\n`), program)
+		fromCSV(flags.Output(), strings.NewReader(exampleCSV), outputOptions{format: "compact", color: "never"}, tabular.Options{Numbers: true, Booleans: true, Nulls: true}, false)
+		_, _ = fmt.Fprintln(flags.Output(), "> Flags:")
+		flags.PrintDefaults()
+	}
+	_ = flags.Parse(args)
+	if flags.NArg() > 1 {
+		flags.Usage()
+		os.Exit(2)
+	}
+	output.validate()
+	options, err := inference(infer)
+	if err != nil {
+		log.Fatalln(err)
+	}
+	if tsv {
+		options.Comma = '\t'
+	}
+	if flags.NArg() == 0 {
+		fromCSV(os.Stdout, os.Stdin, *output, options, ndjson)
+		return
+	}
+	file, err := os.Open(flags.Arg(0))
+	if err != nil {
+		log.Fatalln(err)
+	}
+	defer func() { _ = file.Close() }()
+	fromCSV(os.Stdout, file, *output, options, ndjson)
+}
+func inference(list string) (options tabular.Options, err error) {
+	for _, name := range strings.Split(list, ",") {
+		switch strings.TrimSpace(name) {
+		case "all":
+			options.Numbers, options.Booleans, options.Nulls = true, true, true
+		case "none", "":
+		case "numbers":
+			options.Numbers = true
+		case "booleans":
+			options.Booleans = true
+		case "nulls":
+			options.Nulls = true
+		default:
+			return options, fmt.Errorf("invalid inference: %q", name)
+		}
+	}
+	return options, nil
+}
+
+// fromCSV streams the rows, rendering each through the printer as it is read,
+// as the elements of an array (or as NDJSON lines).
+func fromCSV(output io.Writer, input io.Reader, options outputOptions, csvOptions tabular.Options, ndjson bool) {
+	reader := tabular.NewReader(input, csvOptions)
+	if ndjson {
+		options.format = "compact"
+	}
+	printer := options.newPrinter(output)
+	if !ndjson {
+		printer.Print(lexing.Token{Type: lexing.TokenArrayStart, Value: []byte("[")})
+	}
+	rowCount := 0
+	for ; ; rowCount++ {
+		row, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			log.Fatalln(err)
+		}
+		if ndjson {
+			parsing.Render(row, options.newPrinter(output))
+			options.endLine(output)
+			continue
+		}
+		if rowCount > 0 {
+			printer.Print(lexing.Token{Type: lexing.TokenComma, Value: []byte(",")})
+		}
+		parsing.Render(row, printer)
+	}
+	if !ndjson {
+		printer.Print(lexing.Token{Type: lexing.TokenArrayStop, Value: []byte("]")})
+		options.endLine(output)
+	}
+	log.Printf("Converted %d CSV row(s) to JSON.", rowCount)
+}
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"encoding/hex"
+	"flag"
+	"fmt"
+	"hash"
+	"io"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/mdwhatcott/coding-challenges.fyi-json/lib/hashing"
+	"github.com/mdwhatcott/coding-challenges.fyi-json/lib/parsing"
+)
+
+func hashCommand(program string, args []string) {
+	var algorithmName string
+	var subtrees bool
+	flags := flag.NewFlagSet(fmt.Sprintf("%s hash @ %s", program, Version), flag.ExitOnError)
+	flags.StringVar(&algorithmName, "algorithm", "sha256", "Hash algorithm, one of '"+strings.Join(hashing.AlgorithmNames(), "', '")+"'.")
+	flags.BoolVar(&subtrees, "subtrees", false, "Hash every value in the document, listing each as NAME#POINTER (a JSON Pointer URI fragment).")
+	flags.Usage = func() {
+		_, _ = fmt.Fprintln(flags.Output(), flags.Name())
+		_, _ = fmt.Fprintln(flags.Output(), "> Hashes the canonical (RFC 8785) form of each JSON document (or stdin), so formatting doesn't affect the hash.")
+		_, _ = fmt.Fprintln(flags.Output(), "> Example usage:")
+		_, _ = fmt.Fprintf(flags.Output(), "$ echo -n '%s' | %s hash\n", exampleInput, program)
+		example, _ := parsing.ParseBytes([]byte(exampleInput))
+		hashJSON(flags.Output(), "-", example, hashing.Algorithms["sha256"], false)
+		_, _ = fmt.Fprintln(flags.Output(), "> Flags:")
+		flags.PrintDefaults()
+	}
+	_ = flags.Parse(args)
+	algorithm, ok := hashing.LookupAlgorithm(algorithmName)
+	if !ok {
+		log.Fatalln("Invalid hash algorithm:", algorithmName)
+	}
+	if flags.NArg() == 0 {
+		document, err := hashing.Parse(os.Stdin)
+		if err != nil {
+			log.Fatalln(err)
+		}
+		hashJSON(os.Stdout, "-", document, algorithm, subtrees)
+	}
+	for _, path := range flags.Args() {
+		hashJSON(os.Stdout, path, parseHashable(path), algorithm, subtrees)
+	}
+}
+
+// parseHashable is parseFile for documents to hash (see hashing.Parse).
+func parseHashable(path string) any {
+	file, err := os.Open(path)
+	if err != nil {
+		log.Fatalln(err)
+	}
+	defer func() { _ = file.Close() }()
+	document, err := hashing.Parse(file)
+	if err != nil {
+		log.Fatalf("%s: %v", path, err)
+	}
+	return document
+}
+func hashJSON(output io.Writer, name string, document any, algorithm func() hash.Hash, subtrees bool) {
+	if !subtrees {
+		sum, err := hashing.Sum(document, algorithm)
+		if err != nil {
+			log.Fatalf("%s: %v", name, err)
+		}
+		_, _ = fmt.Fprintf(output, "%s  %s\n", hex.EncodeToString(sum), name)
+		return
+	}
+	all, err := hashing.Subtrees(document, algorithm)
+	if err != nil {
+		log.Fatalf("%s: %v", name, err)
+	}
+	if name == "-" {
+		name = ""
+	}
+	for _, subtree := range all {
+		_, _ = fmt.Fprintf(output, "%s  %s#%s\n", hex.EncodeToString(subtree.Sum), name, subtree.Path.Fragment())
+	}
+}
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+
+	"github.com/mdwhatcott/coding-challenges.fyi-json/lib/jq"
+	"github.com/mdwhatcott/coding-challenges.fyi-json/lib/parsing"
+)
+
+func jqCommand(program string, args []string) {
+	flags := flag.NewFlagSet(fmt.Sprintf("%s jq @ %s", program, Version), flag.ExitOnError)
+	output := outputFlags(flags, "How to format each result")
+	flags.Usage = func() {
+		_, _ = fmt.Fprintln(flags.Output(), flags.Name())
+		_, _ = fmt.Fprintln(flags.Output(), "> Applies a jq filter to the JSON document on stdin, outputs each result to stdout.")
+		_, _ = fmt.Fprintln(flags.Output(), "> Example usage:")
+		_, _ = fmt.Fprintf(flags.Output(), `$ echo -n '%s' | %s jq -fmt compact '{foo, count: .baz | length}'`+"\n", exampleInput, program)
+		_, _ = fmt.Fprintln(flags.Output(), `{"foo":"bar","count":3}`)
+		_, _ = fmt.Fprintln(flags.Output(), "> Flags:")
+		flags.PrintDefaults()
+	}
+	_ = flags.Parse(args)
+	if flags.NArg() != 1 {
+		flags.Usage()
+		os.Exit(2)
+	}
+	output.validate()
+	filterJSON(os.Stdout, os.Stdin, *output, flags.Arg(0))
+}
+func filterJSON(output io.Writer, input io.Reader, options outputOptions, program string) {
+	filter, err := jq.Compile(program)
+	if err != nil {
+		log.Fatalln(err)
+	}
+	document, err := parsing.Parse(input)
+	if err != nil {
+		log.Fatalln(err)
+	}
+	results, err := filter.Apply(document)
+	for _, result := range results {
+		parsing.Render(result, options.newPrinter(output))
+		options.endLine(output)
+	}
+	if err != nil {
+		log.Fatalln("jq:", err)
+	}
+}
@@ -10,7 +10,11 @@ import (
 	"path/filepath"
 
 	"github.com/mdwhatcott/coding-challenges.fyi-json/lib/lexing"
-	"github.com/mdwhatcott/coding-challenges.fyi-json/lib/printing"
+	"github.com/mdwhatcott/coding-challenges.fyi-json/lib/parsing"
+	"github.com/mdwhatcott/coding-challenges.fyi-json/lib/pointer"
+	"github.com/mdwhatcott/coding-challenges.fyi-json/lib/query"
+	"github.com/mdwhatcott/coding-challenges.fyi-json/lib/streaming"
+	"github.com/mdwhatcott/coding-challenges.fyi-json/lib/validating"
 )
 
 var Version = "dev"
@@ -18,54 +22,184 @@ var Version = "dev"
 const exampleInput = `{"foo":"bar","baz":[1,2,3]}`
 
 func main() {
-	var format string
+	var split bool
+	var pointerText string
+	var path string
+	var duplicates string
+	var profileName string
 	log.SetFlags(0)
 	log.SetPrefix("[LOG] ")
 	program := filepath.Base(os.Args[0])
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "jq":
+			jqCommand(program, os.Args[2:])
+			return
+		case "diff":
+			diffCommand(program, os.Args[2:])
+			return
+		case "patch":
+			patchCommand(program, os.Args[2:])
+			return
+		case "merge":
+			mergeCommand(program, os.Args[2:])
+			return
+		case "hash":
+			hashCommand(program, os.Args[2:])
+			return
+		case "ungron":
+			ungronCommand(program, os.Args[2:])
+			return
+		case "flatten", "unflatten":
+			flattenCommand(program, os.Args[2:], os.Args[1] == "unflatten")
+			return
+		case "from-csv":
+			fromCSVCommand(program, os.Args[2:])
+			return
+		}
+	}
 	flags := flag.NewFlagSet(fmt.Sprintf("%s @ %s", program, Version), flag.ExitOnError)
-	flags.StringVar(&format, "fmt", "colors", "How to format the output, one of 'colors', 'indent', 'compact', 'verbatim'.")
+	output := outputFlags(flags, "How to format the output")
+	flags.StringVar(&pointerText, "pointer", "", "JSON Pointer (RFC 6901) of the value to output, such as '/a/b/0' (default: the whole document).")
+	flags.StringVar(&path, "path", "", "JSONPath (RFC 9535) query, such as '$.items[?@.price > 10].name'; each match is output with its normalized path.")
+	flags.BoolVar(&split, "split", false, "Split the top-level array (or the array at -pointer) into NDJSON output, one compact element per line (ignores -fmt).")
+	flags.StringVar(&duplicates, "duplicates", "warn", "How to treat duplicate object keys, one of 'ignore', 'warn', 'error'.")
+	flags.StringVar(&profileName, "profile", "grammar", "Validation profile, one of 'grammar', 'rfc8259' (UTF-8 only), 'i-json' (RFC 7493).")
 	flags.Usage = func() {
 		_, _ = fmt.Fprintln(flags.Output(), flags.Name())
 		_, _ = fmt.Fprintln(flags.Output(), "> Validates JSON data from stdin, outputs JSON to stdout.")
 		_, _ = fmt.Fprintln(flags.Output(), "> Example usage:")
 		_, _ = fmt.Fprintf(flags.Output(), `$ echo -n '%s' | %s -fmt indent`+"\n", exampleInput, program)
-		validateJSON(flags.Output(), bytes.NewBufferString(exampleInput), "indent")
+		validateJSON(flags.Output(), bytes.NewBufferString(exampleInput), outputOptions{format: "indent", color: "never", indent: 2}, "ignore", validating.Grammar)
+		_, _ = fmt.Fprintln(flags.Output(), "> Subcommands:")
+		_, _ = fmt.Fprintf(flags.Output(), "  %s jq FILTER\n    \tApply a jq filter (see '%s jq -h').\n", program, program)
+		_, _ = fmt.Fprintf(flags.Output(), "  %s diff A B\n    \tCompare two JSON documents (see '%s diff -h').\n", program, program)
+		_, _ = fmt.Fprintf(flags.Output(), "  %s patch DOCUMENT OPERATIONS\n    \tApply a JSON Patch (see '%s patch -h').\n", program, program)
+		_, _ = fmt.Fprintf(flags.Output(), "  %s merge BASE PATCH\n    \tApply a JSON Merge Patch (see '%s merge -h').\n", program, program)
+		_, _ = fmt.Fprintf(flags.Output(), "  %s hash [FILE...]\n    \tHash documents independent of formatting (see '%s hash -h').\n", program, program)
+		_, _ = fmt.Fprintf(flags.Output(), "  %s ungron [FILE]\n    \tRebuild JSON from '-fmt gron' statements (see '%s ungron -h').\n", program, program)
+		_, _ = fmt.Fprintf(flags.Output(), "  %s flatten [FILE]\n    \tFlatten nested values into a single-level object (see '%s flatten -h').\n", program, program)
+		_, _ = fmt.Fprintf(flags.Output(), "  %s unflatten [FILE]\n    \tReverse 'flatten' (see '%s unflatten -h').\n", program, program)
+		_, _ = fmt.Fprintf(flags.Output(), "  %s from-csv [FILE]\n    \tConvert CSV or TSV rows into JSON objects (see '%s from-csv -h').\n", program, program)
 		_, _ = fmt.Fprintln(flags.Output(), "> Flags:")
 		flags.PrintDefaults()
 	}
 	_ = flags.Parse(os.Args[1:])
-	if format != "colors" && format != "indent" && format != "compact" && format != "verbatim" {
-		log.Fatalln("Invalid output format:", format)
+	output.validate()
+	if duplicates != "ignore" && duplicates != "warn" && duplicates != "error" {
+		log.Fatalln("Invalid duplicate key treatment:", duplicates)
+	}
+	profile, ok := validating.LookupProfile(profileName)
+	if !ok {
+		log.Fatalln("Invalid validation profile:", profileName)
+	}
+	if profile.DuplicateKeys {
+		duplicates = "ignore" // reported as profile violations instead
+	}
+	target, err := pointer.Parse(pointerText)
+	if err != nil {
+		log.Fatalln(err)
 	}
 
-	validateJSON(os.Stdout, os.Stdin, format)
+	if path != "" {
+		queryJSON(os.Stdout, os.Stdin, *output, path)
+		return
+	}
+	if split {
+		splitJSON(os.Stdout, os.Stdin, *output, target)
+		return
+	}
+	if len(target) > 0 {
+		extractJSON(os.Stdout, os.Stdin, *output, target)
+		return
+	}
+	validateJSON(os.Stdout, output.scanColumns(os.Stdin), *output, duplicates, profile)
 }
-func validateJSON(output io.Writer, input io.Reader, format string) {
+func validateJSON(output io.Writer, input io.Reader, options outputOptions, duplicates string, profile validating.Profile) {
 	byteCount := 0
 	tokenCount := 0
-	printer := newPrinter(output, format)
+	printer := options.newPrinter(output)
+	duplicateKeys := validating.NewDuplicateKeys()
+	walker := streaming.NewWalker(duplicateKeys)
+	checker := validating.NewChecker(profile)
 	for token := range lexing.Lex(input) {
 		printer.Print(token)
 		if token.Type == lexing.TokenIllegal {
 			log.Fatalf("Illegal token at index %d: %s", byteCount, token.Value)
 		}
+		_ = walker.Walk(token)
+		checker.Check(token)
 		tokenCount++
 		byteCount += len(token.Value)
 	}
-	fmt.Println()
+	options.endLine(output)
+	if duplicates != "ignore" {
+		for _, violation := range duplicateKeys.Violations {
+			log.Println(violation)
+		}
+		if duplicates == "error" && len(duplicateKeys.Violations) > 0 {
+			log.Fatalf("JSON document contains %d duplicate key(s).", len(duplicateKeys.Violations))
+		}
+	}
+	violations := checker.Violations()
+	for _, violation := range violations {
+		log.Println(violation)
+	}
+	if len(violations) > 0 {
+		log.Fatalf("JSON document violates the %s profile %d time(s).", profile.Name, len(violations))
+	}
 	log.Printf("JSON document with %d bytes and %d tokens validated successfully.", byteCount, tokenCount)
 }
-func newPrinter(output io.Writer, format string) printing.Printer {
-	switch format {
-	case "colors":
-		return printing.NewColorPrinter(output, printing.NewIndentingPrinter(output))
-	case "indent":
-		return printing.NewIndentingPrinter(output)
-	case "compact":
-		return printing.NewCompactPrinter(output)
-	case "verbatim":
-		return printing.NewVerbatimPrinter(output)
-	default:
-		panic("invalid format: " + format)
+func extractJSON(output io.Writer, input io.Reader, options outputOptions, target pointer.Pointer) {
+	err := streaming.Extract(input, target, options.newPrinter(output))
+	if err != nil {
+		log.Fatalln(err)
+	}
+	options.endLine(output)
+}
+func queryJSON(output io.Writer, input io.Reader, options outputOptions, path string) {
+	compiled, err := query.Compile(path)
+	if err != nil {
+		log.Fatalln(err)
+	}
+	root, err := parsing.Parse(input)
+	if err != nil {
+		log.Fatalln(err)
+	}
+	nodes := compiled.Select(root)
+	for _, node := range nodes {
+		_, _ = fmt.Fprintf(output, "%s: ", node.Path())
+		parsing.Render(node.Value, options.newPrinter(output))
+		options.endLine(output)
+	}
+	log.Printf("JSONPath query matched %d node(s).", len(nodes))
+}
+func splitJSON(output io.Writer, input io.Reader, options outputOptions, target pointer.Pointer) {
+	options.format = "compact"
+	elementCount := 0
+	err := streaming.Elements(input, target, func(raw []byte) error {
+		printer := options.newPrinter(output)
+		for token := range lexing.Lex(bytes.NewReader(raw)) {
+			printer.Print(token)
+		}
+		elementCount++
+		_, err := fmt.Fprintln(output)
+		return err
+	})
+	if err != nil {
+		log.Fatalln(err)
+	}
+	log.Printf("Split %d array elements into NDJSON.", elementCount)
+}
+func parseFile(path string) any {
+	file, err := os.Open(path)
+	if err != nil {
+		log.Fatalln(err)
+	}
+	defer func() { _ = file.Close() }()
+	document, err := parsing.Parse(file)
+	if err != nil {
+		log.Fatalf("%s: %v", path, err)
 	}
+	return document
 }
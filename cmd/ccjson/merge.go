@@ -0,0 +1,50 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+
+	"github.com/mdwhatcott/coding-challenges.fyi-json/lib/parsing"
+	"github.com/mdwhatcott/coding-challenges.fyi-json/lib/patching"
+)
+
+func mergeCommand(program string, args []string) {
+	var generate bool
+	flags := flag.NewFlagSet(fmt.Sprintf("%s merge @ %s", program, Version), flag.ExitOnError)
+	output := outputFlags(flags, "How to format the output")
+	flags.BoolVar(&generate, "generate", false, "Instead of applying PATCH to BASE, output the merge patch that transforms BASE into the second document.")
+	flags.Usage = func() {
+		_, _ = fmt.Fprintln(flags.Output(), flags.Name())
+		_, _ = fmt.Fprintln(flags.Output(), "> Applies a JSON Merge Patch (RFC 7396) to a base document, outputs the merged document to stdout.")
+		_, _ = fmt.Fprintln(flags.Output(), "> Null members of the patch delete members of the base; the base's key order is preserved.")
+		_, _ = fmt.Fprintln(flags.Output(), "> Example usage:")
+		_, _ = fmt.Fprintf(flags.Output(), "$ %s merge -fmt compact base.json patch.json\n", program)
+		_, _ = fmt.Fprintf(flags.Output(), "$ %s merge -generate base.json desired.json > patch.json\n", program)
+		_, _ = fmt.Fprintln(flags.Output(), "> Flags:")
+		flags.PrintDefaults()
+	}
+	_ = flags.Parse(args)
+	if flags.NArg() != 2 {
+		flags.Usage()
+		os.Exit(2)
+	}
+	output.validate()
+	mergeJSON(os.Stdout, parseFile(flags.Arg(0)), parseFile(flags.Arg(1)), *output, generate)
+}
+func mergeJSON(output io.Writer, base, other any, options outputOptions, generate bool) {
+	var result any
+	if generate {
+		var err error
+		result, err = patching.GenerateMerge(base, other)
+		if err != nil {
+			log.Fatalln(err)
+		}
+	} else {
+		result = patching.Merge(base, other)
+	}
+	parsing.Render(result, options.newPrinter(output))
+	options.endLine(output)
+}
@@ -0,0 +1,226 @@
+package main
+
+import (
+	"flag"
+	"io"
+	"log"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/mdwhatcott/coding-challenges.fyi-json/lib/printing"
+)
+
+const colorsVariable = "CCJSON_COLORS"
+
+// The "colors" format predates -color and is short for -fmt indent -color always.
+var formats = []string{"indent", "pretty", "compact", "verbatim", "canonical", "html", "gron", "yaml", "csv", "tsv", "colors"}
+
+type outputOptions struct {
+	format      string
+	color       string
+	sortKeys    bool
+	indent      int
+	tab         bool
+	crlf        bool
+	width       int
+	align       int
+	alignColons bool
+	themeName   string
+	theme       printing.Theme
+	htmlPage    bool
+	htmlAnchor  string
+	columns     []string                 // of CSV/TSV output, when known up front (see scanColumns)
+	checked     interface{ Err() error } // the last printer that reports errors of its own (see endLine)
+	closer      io.Closer                // the last printer that must be closed after the document (see endLine)
+}
+
+// outputFlags registers the flags shared by every command that outputs JSON documents.
+func outputFlags(flags *flag.FlagSet, description string) *outputOptions {
+	options := &outputOptions{}
+	flags.StringVar(&options.format, "fmt", "indent", description+", one of "+formatNames()+".")
+	flags.StringVar(&options.color, "color", "auto", "When to color the output, one of 'auto' (when stdout is a terminal; honors NO_COLOR and FORCE_COLOR), 'always', 'never'.")
+	flags.BoolVar(&options.sortKeys, "sort-keys", false, "Sort object members by key, recursively.")
+	flags.IntVar(&options.indent, "indent", 2, "Number of spaces per level of nesting ('indent' and 'pretty' formats).")
+	flags.BoolVar(&options.tab, "tab", false, "Indent with tabs instead of spaces ('indent' and 'pretty' formats).")
+	flags.BoolVar(&options.crlf, "crlf", false, "End lines with CRLF instead of LF ('indent', 'pretty', 'csv' and 'tsv' formats).")
+	flags.IntVar(&options.align, "align", 0, "Line up the values of each object in a column, padding keys up to this width ('indent' format; 0 disables).")
+	flags.BoolVar(&options.alignColons, "align-colons", false, "With -align, line up the colons rather than the values.")
+	flags.IntVar(&options.width, "width", 80, "Line width within which the 'pretty' format keeps arrays and objects on one line.")
+	flags.StringVar(&options.themeName, "theme", "default", "Color theme, one of '"+strings.Join(printing.ThemeNames(), "', '")+"'. "+
+		"Override individual colors with "+colorsVariable+" (null:false:true:numbers:strings:arrays:objects:keys, each like '1;31', '38;5;208' or '#rrggbb').")
+	flags.BoolVar(&options.htmlPage, "html-page", false, "Output a standalone page with embedded CSS ('html' format).")
+	flags.StringVar(&options.htmlAnchor, "html-anchor", "json", "Prefix of the id of each element, followed by its JSON Pointer ('html' format).")
+	return options
+}
+func (this *outputOptions) validate() {
+	if !slices.Contains(formats, this.format) {
+		log.Fatalln("Invalid output format:", this.format)
+	}
+	validateColorMode(this.color)
+	if this.indent < 0 {
+		log.Fatalln("Invalid indent width:", this.indent)
+	}
+	if this.align < 0 {
+		log.Fatalln("Invalid alignment width:", this.align)
+	}
+	if this.alignColons && this.align == 0 {
+		log.Fatalln("-align-colons requires -align.")
+	}
+	theme, ok := printing.LookupTheme(this.themeName)
+	if !ok {
+		log.Fatalln("Invalid color theme:", this.themeName)
+	}
+	if this.colored(os.Stdout) && this.colorable() {
+		var err error
+		theme, err = printing.ParseColors(os.Getenv(colorsVariable), theme)
+		if err != nil {
+			log.Fatalf("Invalid %s: %v", colorsVariable, err)
+		}
+	}
+	this.theme = theme
+}
+func (this *outputOptions) newPrinter(output io.Writer) (printer printing.Printer) {
+	colored := this.colored(output)
+	switch this.format {
+	case "indent", "colors":
+		printer = printing.NewIndentingPrinter(output, this.indentOptions()...)
+	case "pretty":
+		printer = printing.NewPrettyPrinter(output, this.width, this.indentOptions()...)
+	case "compact":
+		printer = printing.NewCompactPrinter(output)
+	case "verbatim":
+		printer = printing.NewVerbatimPrinter(output)
+	case "canonical":
+		canonical := printing.NewCanonicalPrinter(output)
+		this.checked, printer = canonical, canonical
+	case "html":
+		html := printing.NewHTMLPrinter(output, this.htmlOptions()...)
+		this.closer, printer = html, html
+	case "gron":
+		printer = printing.NewGronPrinter(output)
+	case "yaml":
+		printer = printing.NewYAMLPrinter(output)
+	case "csv", "tsv":
+		printer = this.newCSVPrinter(output)
+	default:
+		panic("invalid format: " + this.format)
+	}
+	if colored && this.colorable() {
+		printer = printing.NewInlineColorPrinter(printer, printing.ColorTheme(this.theme))
+	}
+	if this.sortKeys {
+		printer = printing.NewSortingPrinter(printer)
+	}
+	return printer
+}
+func (this *outputOptions) colored(output io.Writer) bool {
+	if this.format == "colors" {
+		return this.color != "never"
+	}
+	return colorEnabled(this.color, output)
+}
+
+// colorable reports whether the format can be colored with ANSI escape codes
+// (canonical output must stay byte-stable, HTML has its own highlighting, and
+// the printers of the other formats rewrite the JSON text of each token).
+func (this *outputOptions) colorable() bool {
+	return !slices.Contains([]string{"canonical", "html", "gron", "yaml", "csv", "tsv"}, this.format)
+}
+func (this *outputOptions) newCSVPrinter(output io.Writer) printing.Printer {
+	var options []printing.CSVOption
+	if this.columns != nil {
+		options = append(options, printing.CSVColumns(this.columns))
+	}
+	if this.format == "tsv" {
+		options = append(options, printing.CSVComma('\t'))
+	}
+	if this.crlf {
+		options = append(options, printing.CSVCRLF())
+	}
+	printer := printing.NewCSVPrinter(output, options...)
+	this.checked = printer
+	return printer
+}
+
+// scanColumns makes a first pass over the input for the columns of CSV/TSV
+// output, so the rows can be streamed on the second pass rather than buffered
+// (stdin is spooled to a temporary file unless it can be rewound). It returns
+// the input for the second pass.
+func (this *outputOptions) scanColumns(input *os.File) *os.File {
+	if this.format != "csv" && this.format != "tsv" {
+		return input
+	}
+	offset, err := input.Seek(0, io.SeekCurrent)
+	if err != nil {
+		spool, err := os.CreateTemp("", "ccjson-*.json")
+		if err != nil {
+			log.Fatalln(err)
+		}
+		_ = os.Remove(spool.Name()) // (still readable until closed, where supported)
+		if _, err = io.Copy(spool, input); err != nil {
+			log.Fatalln(err)
+		}
+		input, offset = spool, 0
+	}
+	if _, err = input.Seek(offset, io.SeekStart); err != nil {
+		log.Fatalln(err)
+	}
+	this.columns = printing.ReadCSVColumns(input)
+	if this.sortKeys {
+		slices.Sort(this.columns)
+	}
+	if this.columns == nil {
+		this.columns = []string{}
+	}
+	if _, err = input.Seek(offset, io.SeekStart); err != nil {
+		log.Fatalln(err)
+	}
+	return input
+}
+func (this *outputOptions) htmlOptions() (options []printing.HTMLOption) {
+	options = append(options, printing.HTMLAnchorPrefix(this.htmlAnchor))
+	if this.htmlPage {
+		options = append(options, printing.HTMLStandalone("JSON"))
+	}
+	return options
+}
+func (this *outputOptions) indentOptions() (options []printing.IndentOption) {
+	options = append(options, printing.IndentWidth(this.indent))
+	if this.tab {
+		options = append(options, printing.IndentTabs())
+	}
+	if this.crlf {
+		options = append(options, printing.IndentCRLF())
+	}
+	if this.align > 0 && this.alignColons {
+		options = append(options, printing.IndentAlignColons(this.align))
+	} else if this.align > 0 {
+		options = append(options, printing.IndentAlignValues(this.align))
+	}
+	return options
+}
+
+// endLine finishes the output of a document, failing if the printer couldn't
+// print it (CSV/TSV rows end their own lines).
+func (this *outputOptions) endLine(output io.Writer) {
+	if this.closer != nil {
+		_ = this.closer.Close()
+	}
+	if this.checked != nil {
+		if err := this.checked.Err(); err != nil {
+			log.Fatalln(err)
+		}
+	}
+	if this.format == "csv" || this.format == "tsv" {
+		return
+	}
+	if this.crlf {
+		_, _ = io.WriteString(output, "\r\n")
+	} else {
+		_, _ = io.WriteString(output, "\n")
+	}
+}
+func formatNames() string {
+	return "'" + strings.Join(formats, "', '") + "'"
+}
@@ -0,0 +1,46 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+
+	"github.com/mdwhatcott/coding-challenges.fyi-json/lib/parsing"
+	"github.com/mdwhatcott/coding-challenges.fyi-json/lib/patching"
+)
+
+func patchCommand(program string, args []string) {
+	flags := flag.NewFlagSet(fmt.Sprintf("%s patch @ %s", program, Version), flag.ExitOnError)
+	output := outputFlags(flags, "How to format the patched document")
+	flags.Usage = func() {
+		_, _ = fmt.Fprintln(flags.Output(), flags.Name())
+		_, _ = fmt.Fprintln(flags.Output(), "> Applies a JSON Patch (RFC 6902) to a document, outputs the patched document to stdout.")
+		_, _ = fmt.Fprintln(flags.Output(), "> The operations are applied atomically: if one fails, nothing is output.")
+		_, _ = fmt.Fprintln(flags.Output(), "> Example usage:")
+		_, _ = fmt.Fprintf(flags.Output(), "$ %s patch -fmt compact doc.json ops.json\n", program)
+		_, _ = fmt.Fprintln(flags.Output(), "> Flags:")
+		flags.PrintDefaults()
+	}
+	_ = flags.Parse(args)
+	if flags.NArg() != 2 {
+		flags.Usage()
+		os.Exit(2)
+	}
+	output.validate()
+	patchJSON(os.Stdout, parseFile(flags.Arg(0)), parseFile(flags.Arg(1)), *output)
+}
+func patchJSON(output io.Writer, document, patch any, options outputOptions) {
+	operations, err := patching.ParseOperations(patch)
+	if err != nil {
+		log.Fatalln(err)
+	}
+	result, err := patching.Apply(document, operations)
+	if err != nil {
+		log.Fatalln(err)
+	}
+	parsing.Render(result, options.newPrinter(output))
+	options.endLine(output)
+	log.Printf("Applied %d patch operation(s).", len(operations))
+}
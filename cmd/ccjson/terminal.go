@@ -0,0 +1,40 @@
+package main
+
+import (
+	"io"
+	"log"
+	"os"
+	"slices"
+)
+
+var colorModes = []string{"auto", "always", "never"}
+
+// colorEnabled decides whether output should be colored. In "auto" mode,
+// FORCE_COLOR (set to anything but "0") enables color, NO_COLOR (set to
+// anything) disables it, and otherwise color is used only when output is a
+// terminal (and TERM isn't "dumb").
+func colorEnabled(mode string, output io.Writer) bool {
+	switch mode {
+	case "always":
+		return true
+	case "never":
+		return false
+	}
+	if force := os.Getenv("FORCE_COLOR"); force != "" && force != "0" {
+		return true
+	}
+	if os.Getenv("NO_COLOR") != "" || os.Getenv("TERM") == "dumb" {
+		return false
+	}
+	file, ok := output.(*os.File)
+	return ok && isTerminal(file)
+}
+func isTerminal(file *os.File) bool {
+	info, err := file.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+func validateColorMode(mode string) {
+	if !slices.Contains(colorModes, mode) {
+		log.Fatalln("Invalid color mode:", mode)
+	}
+}
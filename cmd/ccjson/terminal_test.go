@@ -0,0 +1,59 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/mdwhatcott/testing/should"
+)
+
+func TestColorEnabled(t *testing.T) {
+	tests := []struct {
+		name     string
+		mode     string
+		force    string
+		noColor  string
+		term     string
+		expected bool
+	}{
+		{name: "always", mode: "always", noColor: "1", expected: true},
+		{name: "never", mode: "never", force: "1", expected: false},
+		{name: "auto, not a terminal", mode: "auto", expected: false},
+		{name: "auto, FORCE_COLOR", mode: "auto", force: "1", expected: true},
+		{name: "auto, FORCE_COLOR=0", mode: "auto", force: "0", expected: false},
+		{name: "auto, FORCE_COLOR over NO_COLOR", mode: "auto", force: "1", noColor: "1", expected: true},
+		{name: "auto, NO_COLOR", mode: "auto", noColor: "1", expected: false},
+		{name: "auto, dumb terminal", mode: "auto", term: "dumb", expected: false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Setenv("FORCE_COLOR", test.force)
+			t.Setenv("NO_COLOR", test.noColor)
+			t.Setenv("TERM", test.term)
+			should.So(t, colorEnabled(test.mode, &bytes.Buffer{}), should.Equal, test.expected)
+		})
+	}
+}
+func TestOutputOptionsColored(t *testing.T) {
+	tests := []struct {
+		format   string
+		color    string
+		expected bool
+	}{
+		{format: "colors", color: "auto", expected: true}, // the old way to ask for color, even when piped
+		{format: "colors", color: "always", expected: true},
+		{format: "colors", color: "never", expected: false},
+		{format: "indent", color: "auto", expected: false},
+		{format: "indent", color: "always", expected: true},
+		{format: "indent", color: "never", expected: false},
+	}
+	for _, test := range tests {
+		t.Run(test.format+"/"+test.color, func(t *testing.T) {
+			t.Setenv("FORCE_COLOR", "")
+			t.Setenv("NO_COLOR", "")
+			t.Setenv("TERM", "")
+			options := outputOptions{format: test.format, color: test.color}
+			should.So(t, options.colored(&bytes.Buffer{}), should.Equal, test.expected)
+		})
+	}
+}
@@ -0,0 +1,52 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/mdwhatcott/coding-challenges.fyi-json/lib/gron"
+	"github.com/mdwhatcott/coding-challenges.fyi-json/lib/parsing"
+)
+
+func ungronCommand(program string, args []string) {
+	flags := flag.NewFlagSet(fmt.Sprintf("%s ungron @ %s", program, Version), flag.ExitOnError)
+	output := outputFlags(flags, "How to format the output")
+	flags.Usage = func() {
+		_, _ = fmt.Fprintln(flags.Output(), flags.Name())
+		_, _ = fmt.Fprintln(flags.Output(), "> Rebuilds a JSON document from gron statements (as output by '-fmt gron') read from a file (or stdin), outputs JSON to stdout.")
+		_, _ = fmt.Fprintln(flags.Output(), "> Statements may be filtered or reordered; missing containers are created as needed.")
+		_, _ = fmt.Fprintln(flags.Output(), "> Example usage:")
+		_, _ = fmt.Fprintf(flags.Output(), "$ echo -n '%s' | %s -fmt gron | grep '\\[2\\]' | %s ungron -fmt compact\n", exampleInput, program, program)
+		ungronJSON(flags.Output(), strings.NewReader("json.baz[2] = 3;"), outputOptions{format: "compact", color: "never"})
+		_, _ = fmt.Fprintln(flags.Output(), "> Flags:")
+		flags.PrintDefaults()
+	}
+	_ = flags.Parse(args)
+	if flags.NArg() > 1 {
+		flags.Usage()
+		os.Exit(2)
+	}
+	output.validate()
+	if flags.NArg() == 0 {
+		ungronJSON(os.Stdout, os.Stdin, *output)
+		return
+	}
+	file, err := os.Open(flags.Arg(0))
+	if err != nil {
+		log.Fatalln(err)
+	}
+	defer func() { _ = file.Close() }()
+	ungronJSON(os.Stdout, file, *output)
+}
+func ungronJSON(output io.Writer, input io.Reader, options outputOptions) {
+	document, err := gron.Ungron(input)
+	if err != nil {
+		log.Fatalln(err)
+	}
+	parsing.Render(document, options.newPrinter(output))
+	options.endLine(output)
+}
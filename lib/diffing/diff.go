@@ -0,0 +1,207 @@
+package diffing
+
+import (
+	"strconv"
+
+	"github.com/mdwhatcott/coding-challenges.fyi-json/lib/parsing"
+	"github.com/mdwhatcott/coding-challenges.fyi-json/lib/pointer"
+)
+
+type Kind string
+
+const (
+	Added     Kind = "added"
+	Removed   Kind = "removed"
+	Changed   Kind = "changed"
+	Reordered Kind = "reordered" // Old and New hold the object's keys in their respective orders.
+)
+
+// Change describes one difference between two documents. The Path of
+// removed, changed and reordered values refers to the first document,
+// the Path of added values to the second.
+type Change struct {
+	Kind Kind
+	Path pointer.Pointer
+	Old  any
+	New  any
+}
+
+type Options struct {
+	IgnoreKeyOrder   bool
+	IgnoreArrayOrder bool
+}
+
+// Compare reports the differences between documents a and b (as produced by parsing.Parse).
+// Numbers are compared by value. Elements of ordered arrays are aligned along their
+// longest common subsequence, so an insertion doesn't register as a change to every
+// element that follows it.
+func Compare(a, b any, options Options) []Change {
+	comparer := &comparer{options: options}
+	comparer.compare(pointer.Pointer{}, a, b)
+	return comparer.changes
+}
+
+type comparer struct {
+	options Options
+	changes []Change
+}
+
+func (this *comparer) compare(path pointer.Pointer, a, b any) {
+	switch a := a.(type) {
+	case []any:
+		if b, ok := b.([]any); ok {
+			this.compareArrays(path, a, b)
+			return
+		}
+	case *parsing.Object:
+		if b, ok := b.(*parsing.Object); ok {
+			this.compareObjects(path, a, b)
+			return
+		}
+	}
+	if !parsing.Equal(a, b) {
+		this.report(Changed, path, a, b)
+	}
+}
+func (this *comparer) compareObjects(path pointer.Pointer, a, b *parsing.Object) {
+	for _, key := range uniqueKeys(a) {
+		aValue, _ := a.Get(key)
+		bValue, ok := b.Get(key)
+		if ok {
+			this.compare(child(path, key), aValue, bValue)
+		} else {
+			this.report(Removed, child(path, key), aValue, nil)
+		}
+	}
+	for _, key := range uniqueKeys(b) {
+		if _, ok := a.Get(key); !ok {
+			value, _ := b.Get(key)
+			this.report(Added, child(path, key), nil, value)
+		}
+	}
+	if this.options.IgnoreKeyOrder {
+		return
+	}
+	aOrder := commonKeys(a, b)
+	bOrder := commonKeys(b, a)
+	for x := range aOrder {
+		if aOrder[x] != bOrder[x] {
+			this.report(Reordered, path, keyList(a), keyList(b))
+			return
+		}
+	}
+}
+func (this *comparer) compareArrays(path pointer.Pointer, a, b []any) {
+	if this.options.IgnoreArrayOrder {
+		this.compareUnordered(path, a, b)
+		return
+	}
+	x, y := 0, 0
+	for _, match := range append(Align(a, b), [2]int{len(a), len(b)}) {
+		for ; x < match[0] && y < match[1]; x, y = x+1, y+1 {
+			this.compare(index(path, x), a[x], b[y])
+		}
+		for ; x < match[0]; x++ {
+			this.report(Removed, index(path, x), a[x], nil)
+		}
+		for ; y < match[1]; y++ {
+			this.report(Added, index(path, y), nil, b[y])
+		}
+		x, y = match[0]+1, match[1]+1
+	}
+}
+func (this *comparer) compareUnordered(path pointer.Pointer, a, b []any) {
+	used := make([]bool, len(a))
+	var added []int
+	for y := range b {
+		found := false
+		for x := range a {
+			if !used[x] && parsing.Equal(a[x], b[y]) {
+				used[x], found = true, true
+				break
+			}
+		}
+		if !found {
+			added = append(added, y)
+		}
+	}
+	for x := range a {
+		if !used[x] {
+			this.report(Removed, index(path, x), a[x], nil)
+		}
+	}
+	for _, y := range added {
+		this.report(Added, index(path, y), nil, b[y])
+	}
+}
+func (this *comparer) report(kind Kind, path pointer.Pointer, old, new any) {
+	this.changes = append(this.changes, Change{Kind: kind, Path: path, Old: old, New: new})
+}
+
+// Align returns the index pairs of the longest common subsequence of
+// (semantically) equal elements of a and b, in order.
+func Align(a, b []any) (matches [][2]int) {
+	lengths := make([][]int, len(a)+1)
+	for x := range lengths {
+		lengths[x] = make([]int, len(b)+1)
+	}
+	for x := len(a) - 1; x >= 0; x-- {
+		for y := len(b) - 1; y >= 0; y-- {
+			if parsing.Equal(a[x], b[y]) {
+				lengths[x][y] = lengths[x+1][y+1] + 1
+			} else {
+				lengths[x][y] = max(lengths[x+1][y], lengths[x][y+1])
+			}
+		}
+	}
+	for x, y := 0, 0; x < len(a) && y < len(b); {
+		switch {
+		case parsing.Equal(a[x], b[y]):
+			matches = append(matches, [2]int{x, y})
+			x, y = x+1, y+1
+		case lengths[x+1][y] >= lengths[x][y+1]:
+			x++
+		default:
+			y++
+		}
+	}
+	return matches
+}
+
+// uniqueKeys lists each key once, at the position of its last (effective) occurrence.
+func uniqueKeys(object *parsing.Object) (keys []string) {
+	for x, member := range object.Members {
+		if isLast(object, x) {
+			keys = append(keys, member.Key)
+		}
+	}
+	return keys
+}
+func isLast(object *parsing.Object, x int) bool {
+	for _, member := range object.Members[x+1:] {
+		if member.Key == object.Members[x].Key {
+			return false
+		}
+	}
+	return true
+}
+func commonKeys(object, other *parsing.Object) (keys []string) {
+	for _, key := range uniqueKeys(object) {
+		if _, ok := other.Get(key); ok {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+func keyList(object *parsing.Object) (keys []any) {
+	for _, key := range uniqueKeys(object) {
+		keys = append(keys, key)
+	}
+	return keys
+}
+func child(path pointer.Pointer, key string) pointer.Pointer {
+	return append(path[:len(path):len(path)], key)
+}
+func index(path pointer.Pointer, x int) pointer.Pointer {
+	return child(path, strconv.Itoa(x))
+}
@@ -0,0 +1,64 @@
+package diffing
+
+import (
+	"testing"
+
+	"github.com/mdwhatcott/coding-challenges.fyi-json/lib/parsing"
+	"github.com/mdwhatcott/coding-challenges.fyi-json/lib/pointer"
+	"github.com/mdwhatcott/testing/should"
+)
+
+func TestCompare_Equivalent(t *testing.T) {
+	should.So(t, compare(t, `{"a": [1, 2.0, {"b": null}]}`, `{"a":[1,2,{"b":null}]}`, Options{}), should.BeEmpty)
+	should.So(t, compare(t, `{"a": 1, "b": 2}`, `{"b": 2, "a": 1}`, Options{IgnoreKeyOrder: true}), should.BeEmpty)
+	should.So(t, compare(t, `[1, [2, 3], 4]`, `[4, 1, [2, 3]]`, Options{IgnoreArrayOrder: true}), should.BeEmpty)
+}
+func TestCompare_Scalars(t *testing.T) {
+	should.So(t, compare(t, `1`, `2`, Options{}), should.Equal, []Change{
+		{Kind: Changed, Path: pointer.Pointer{}, Old: parsing.Number("1"), New: parsing.Number("2")},
+	})
+	should.So(t, compare(t, `{"a": "x"}`, `{"a": ["x"]}`, Options{}), should.Equal, []Change{
+		{Kind: Changed, Path: pointer.Pointer{"a"}, Old: "x", New: []any{"x"}},
+	})
+}
+func TestCompare_Objects(t *testing.T) {
+	should.So(t, compare(t, `{"a": 1, "b": {"c": true}, "d/e": 3}`, `{"b": {"c": false}, "a": 1, "f": null}`, Options{IgnoreKeyOrder: true}), should.Equal, []Change{
+		{Kind: Changed, Path: pointer.Pointer{"b", "c"}, Old: true, New: false},
+		{Kind: Removed, Path: pointer.Pointer{"d/e"}, Old: parsing.Number("3")},
+		{Kind: Added, Path: pointer.Pointer{"f"}, New: nil},
+	})
+}
+func TestCompare_KeyOrder(t *testing.T) {
+	should.So(t, compare(t, `{"a": 1, "b": 2, "c": 3}`, `{"a": 1, "c": 3, "b": 2}`, Options{}), should.Equal, []Change{
+		{Kind: Reordered, Path: pointer.Pointer{}, Old: []any{"a", "b", "c"}, New: []any{"a", "c", "b"}},
+	})
+	should.So(t, compare(t, `{"a": 1, "b": 2}`, `{"x": 0, "a": 1, "b": 2}`, Options{}), should.Equal, []Change{
+		{Kind: Added, Path: pointer.Pointer{"x"}, New: parsing.Number("0")},
+	})
+}
+func TestCompare_OrderedArrays(t *testing.T) {
+	should.So(t, compare(t, `[1, 2, 3, 4]`, `[0, 1, 2, 4, 5]`, Options{}), should.Equal, []Change{
+		{Kind: Added, Path: pointer.Pointer{"0"}, New: parsing.Number("0")},
+		{Kind: Removed, Path: pointer.Pointer{"2"}, Old: parsing.Number("3")},
+		{Kind: Added, Path: pointer.Pointer{"4"}, New: parsing.Number("5")},
+	})
+	should.So(t, compare(t, `[1, {"a": 1}, 3]`, `[1, {"a": 2}, 3]`, Options{}), should.Equal, []Change{
+		{Kind: Changed, Path: pointer.Pointer{"1", "a"}, Old: parsing.Number("1"), New: parsing.Number("2")},
+	})
+}
+func TestCompare_UnorderedArrays(t *testing.T) {
+	should.So(t, compare(t, `[1, 2, 2, 3]`, `[3, 2, 4, 1]`, Options{IgnoreArrayOrder: true}), should.Equal, []Change{
+		{Kind: Removed, Path: pointer.Pointer{"2"}, Old: parsing.Number("2")},
+		{Kind: Added, Path: pointer.Pointer{"2"}, New: parsing.Number("4")},
+	})
+}
+func compare(t *testing.T, a, b string, options Options) []Change {
+	return Compare(parse(t, a), parse(t, b), options)
+}
+func parse(t *testing.T, input string) any {
+	value, err := parsing.ParseBytes([]byte(input))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return value
+}
@@ -0,0 +1,72 @@
+package diffing
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+
+	"github.com/mdwhatcott/coding-challenges.fyi-json/lib/parsing"
+	"github.com/mdwhatcott/coding-challenges.fyi-json/lib/printing"
+)
+
+// WriteText writes one line per change, prefixed with '+' (added), '-' (removed)
+// or '~' (changed, reordered), optionally colored with the printing palette.
+func WriteText(out io.Writer, changes []Change, colored bool) {
+	for _, change := range changes {
+		prefix, color := "~", printing.Yellow
+		switch change.Kind {
+		case Added:
+			prefix, color = "+", printing.Green
+		case Removed:
+			prefix, color = "-", printing.Red
+		}
+		if colored {
+			_, _ = out.Write(color)
+		}
+		switch change.Kind {
+		case Added:
+			_, _ = fmt.Fprintf(out, "%s %s: %s", prefix, display(change.Path.String()), compact(change.New))
+		case Removed:
+			_, _ = fmt.Fprintf(out, "%s %s: %s", prefix, display(change.Path.String()), compact(change.Old))
+		case Reordered:
+			_, _ = fmt.Fprintf(out, "%s %s: key order %s -> %s", prefix, display(change.Path.String()), compact(change.Old), compact(change.New))
+		default:
+			_, _ = fmt.Fprintf(out, "%s %s: %s -> %s", prefix, display(change.Path.String()), compact(change.Old), compact(change.New))
+		}
+		if colored {
+			_, _ = out.Write(printing.Reset)
+		}
+		_, _ = fmt.Fprintln(out)
+	}
+}
+
+// Value converts changes to a document for machine-readable output:
+// an array of objects with "kind", "path" and "old" and/or "new" members.
+func Value(changes []Change) any {
+	result := []any{}
+	for _, change := range changes {
+		object := &parsing.Object{}
+		object.Set("kind", string(change.Kind))
+		object.Set("path", change.Path.String())
+		if change.Kind != Added {
+			object.Set("old", change.Old)
+		}
+		if change.Kind != Removed {
+			object.Set("new", change.New)
+		}
+		result = append(result, object)
+	}
+	return result
+}
+
+func display(path string) string {
+	if path == "" {
+		return `""` // the whole document
+	}
+	return path
+}
+func compact(value any) string {
+	var out bytes.Buffer
+	parsing.Render(value, printing.NewCompactPrinter(&out))
+	return out.String()
+}
@@ -0,0 +1,38 @@
+package diffing
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/mdwhatcott/coding-challenges.fyi-json/lib/parsing"
+	"github.com/mdwhatcott/coding-challenges.fyi-json/lib/printing"
+	"github.com/mdwhatcott/testing/should"
+)
+
+const (
+	before = `{"name": "app", "tags": ["a", "b"], "port": 80}`
+	after  = `{"name": "app", "tags": ["a", "c"], "host": "x"}`
+)
+
+func TestWriteText(t *testing.T) {
+	var out bytes.Buffer
+	WriteText(&out, compare(t, before, after, Options{}), false)
+	should.So(t, out.String(), should.Equal, ""+
+		"~ /tags/1: \"b\" -> \"c\"\n"+
+		"- /port: 80\n"+
+		"+ /host: \"x\"\n",
+	)
+}
+func TestWriteText_Colored(t *testing.T) {
+	var out bytes.Buffer
+	WriteText(&out, compare(t, `[1]`, `2`, Options{}), true)
+	should.So(t, out.String(), should.Equal, string(printing.Yellow)+`~ "": [1] -> 2`+string(printing.Reset)+"\n")
+}
+func TestValue(t *testing.T) {
+	var out bytes.Buffer
+	parsing.Render(Value(compare(t, before, after, Options{})), printing.NewCompactPrinter(&out))
+	should.So(t, out.String(), should.Equal, `[`+
+		`{"kind":"changed","path":"/tags/1","old":"b","new":"c"},`+
+		`{"kind":"removed","path":"/port","old":80},`+
+		`{"kind":"added","path":"/host","new":"x"}]`)
+}
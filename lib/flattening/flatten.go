@@ -0,0 +1,207 @@
+package flattening
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/mdwhatcott/coding-challenges.fyi-json/lib/parsing"
+)
+
+var (
+	ErrInvalidSeparator = errors.New("the separator must be non-empty and free of backslashes")
+	ErrConflict         = errors.New("the key extends another key (as \"a.b\" extends \"a\")")
+)
+
+// Flatten converts a document (as produced by parsing.Parse) into an object
+// with one member per leaf, keyed by the keys and array indices of its path
+// joined with separator: {"a": {"b": [{"c": 1}]}} becomes {"a.b.0.c": 1}.
+// Empty arrays and objects are leaves too. Within each key of the document,
+// the backslash and every character of the separator are escaped with a
+// backslash ("a.b" becomes "a\.b"; with "::", "a:b" becomes "a\:b"), so that
+// Unflatten can tell them apart from the joins, even where a key's text and a
+// join would run together. Values other than non-empty arrays and objects are
+// returned as they are.
+func Flatten(document any, separator string) (any, error) {
+	if !validSeparator(separator) {
+		return nil, ErrInvalidSeparator
+	}
+	if isLeaf(document) {
+		return document, nil
+	}
+	this := &flattener{
+		separator: separator,
+		escaper:   escaper(separator),
+		result:    &parsing.Object{},
+	}
+	this.flatten(nil, document)
+	return this.result, nil
+}
+
+type flattener struct {
+	separator string
+	escaper   *strings.Replacer
+	result    *parsing.Object
+}
+
+// escaper escapes the backslash and the characters of the separator.
+func escaper(separator string) *strings.Replacer {
+	replacements := []string{`\`, `\\`}
+	for _, c := range separator {
+		replacements = append(replacements, string(c), `\`+string(c))
+	}
+	return strings.NewReplacer(replacements...)
+}
+
+// flatten adds the leaves of value under path (the escaped keys leading to it).
+func (this *flattener) flatten(path []string, value any) {
+	switch value := value.(type) {
+	case []any:
+		if len(value) > 0 {
+			for x, element := range value {
+				this.flatten(append(path[:len(path):len(path)], strconv.Itoa(x)), element)
+			}
+			return
+		}
+	case *parsing.Object:
+		if value.Len() > 0 {
+			for _, member := range value.Members {
+				this.flatten(append(path[:len(path):len(path)], this.escaper.Replace(member.Key)), member.Value)
+			}
+			return
+		}
+	}
+	key := strings.Join(path, this.separator)
+	this.result.Members = append(this.result.Members, parsing.Member{Key: key, Value: value})
+}
+
+// Unflatten reverses Flatten: each member of the object document is placed at
+// the path given by splitting its key on (unescaped) separators. Objects whose
+// keys are exactly the indices 0 through n-1 (in any order) become arrays.
+// It fails with ErrConflict when a key extends another ({"a": 1, "a.b": 2}).
+// Values other than objects are returned as they are.
+func Unflatten(document any, separator string) (any, error) {
+	if !validSeparator(separator) {
+		return nil, ErrInvalidSeparator
+	}
+	object, ok := document.(*parsing.Object)
+	if !ok {
+		return document, nil
+	}
+	root := &branch{}
+	for _, member := range object.Members {
+		if err := root.insert(split(member.Key, separator), member.Value); err != nil {
+			return nil, fmt.Errorf("key %q: %w", member.Key, err)
+		}
+	}
+	return root.value(), nil
+}
+
+// branch is an object or array under construction, so it can't be confused
+// with the (empty) objects and arrays among the leaves.
+type branch struct {
+	keys     []string
+	children map[string]any // *branch or leaf value
+}
+
+func (this *branch) insert(path []string, value any) error {
+	if this.children == nil {
+		this.children = make(map[string]any)
+	}
+	key := path[0]
+	existing, found := this.children[key]
+	if !found {
+		this.keys = append(this.keys, key)
+	}
+	child, nested := existing.(*branch)
+	if len(path) == 1 {
+		if nested {
+			return ErrConflict
+		}
+		this.children[key] = value
+		return nil
+	}
+	if found && !nested {
+		return ErrConflict
+	}
+	if !found {
+		child = &branch{}
+		this.children[key] = child
+	}
+	return child.insert(path[1:], value)
+}
+func (this *branch) value() any {
+	if this.indexed() {
+		array := make([]any, len(this.keys))
+		for key, child := range this.children {
+			index, _ := strconv.Atoi(key)
+			array[index] = resolve(child)
+		}
+		return array
+	}
+	object := &parsing.Object{}
+	for _, key := range this.keys {
+		object.Members = append(object.Members, parsing.Member{Key: key, Value: resolve(this.children[key])})
+	}
+	return object
+}
+
+// indexed reports whether the keys are exactly "0" through "n-1" (n > 0).
+func (this *branch) indexed() bool {
+	if len(this.keys) == 0 {
+		return false
+	}
+	for _, key := range this.keys {
+		index, err := strconv.Atoi(key)
+		if err != nil || index < 0 || index >= len(this.keys) || strconv.Itoa(index) != key {
+			return false
+		}
+	}
+	return true // (the keys are distinct, so each index appears once)
+}
+func resolve(child any) any {
+	if child, ok := child.(*branch); ok {
+		return child.value()
+	}
+	return child
+}
+
+// split separates key on each separator that isn't escaped, unescaping the
+// parts. A backslash escapes the next character when that is a backslash or a
+// character of the separator, and is otherwise kept as it is.
+func split(key, separator string) (parts []string) {
+	var part strings.Builder
+	for x := 0; x < len(key); x++ {
+		if key[x] == '\\' && x+1 < len(key) {
+			next, size := utf8.DecodeRuneInString(key[x+1:])
+			if next == '\\' || strings.ContainsRune(separator, next) {
+				part.WriteString(key[x+1 : x+1+size])
+				x += size
+				continue
+			}
+		}
+		if strings.HasPrefix(key[x:], separator) {
+			parts = append(parts, part.String())
+			part.Reset()
+			x += len(separator) - 1
+			continue
+		}
+		part.WriteByte(key[x])
+	}
+	return append(parts, part.String())
+}
+
+func validSeparator(separator string) bool {
+	return separator != "" && !strings.Contains(separator, `\`)
+}
+func isLeaf(value any) bool {
+	switch value := value.(type) {
+	case []any:
+		return len(value) == 0
+	case *parsing.Object:
+		return value.Len() == 0
+	}
+	return true
+}
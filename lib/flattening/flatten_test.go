@@ -0,0 +1,83 @@
+package flattening
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/mdwhatcott/coding-challenges.fyi-json/lib/parsing"
+	"github.com/mdwhatcott/coding-challenges.fyi-json/lib/printing"
+	"github.com/mdwhatcott/testing/should"
+)
+
+func TestFlatten(t *testing.T) {
+	testFlatten(t, ".", `{"a":{"b":[{"c":1},2]},"d":"x"}`, `{"a.b.0.c":1,"a.b.1":2,"d":"x"}`)
+	testFlatten(t, ".", `[[1,2],{"a":null}]`, `{"0.0":1,"0.1":2,"1.a":null}`)
+	testFlatten(t, ".", `{"a":{},"b":[],"c":{"d":[]}}`, `{"a":{},"b":[],"c.d":[]}`)
+	testFlatten(t, ".", `{"a.b":{"c\\d":1,"":2}}`, `{"a\\.b.c\\\\d":1,"a\\.b.":2}`)
+	testFlatten(t, "/", `{"a.b":{"c/d":1}}`, `{"a.b/c\\/d":1}`)
+	testFlatten(t, "::", `{"a:b":{"c::d":1}}`, `{"a\\:b::c\\:\\:d":1}`)
+	testFlatten(t, "__", `{"a_":{"b":1}}`, `{"a\\___b":1}`)
+	testFlatten(t, "__", `{"a":{"_b":1}}`, `{"a__\\_b":1}`)
+	testFlatten(t, "__", `{"_":{"":{"__":[1]}}}`, `{"\\_____\\_\\___0":1}`)
+	testFlatten(t, "-x-", `{"a-":{"x":{"-x-b\\":1}}}`, `{"a\\--x-\\x-x-\\-\\x\\-b\\\\":1}`)
+	testFlatten(t, ".", `{"":{"a":1},"a":2}`, `{".a":1,"a":2}`)
+	testFlatten(t, ".", `{"":{"":[""]}}`, `{"..0":""}`)
+	testFlatten(t, ".", `[{"":1}]`, `{"0.":1}`)
+	testFlatten(t, ".", `3`, `3`)
+	testFlatten(t, ".", `{}`, `{}`)
+	testFlatten(t, ".", `[]`, `[]`)
+}
+func testFlatten(t *testing.T, separator, input, expected string) {
+	t.Run(input, func(t *testing.T) {
+		flat, err := Flatten(parse(t, input), separator)
+		should.So(t, err, should.BeNil)
+		should.So(t, render(flat), should.Equal, expected)
+
+		nested, err := Unflatten(flat, separator)
+		should.So(t, err, should.BeNil)
+		should.So(t, render(nested), should.Equal, input)
+	})
+}
+func TestUnflatten(t *testing.T) {
+	testUnflatten(t, `{"a.1":"y","a.0":"x","b.0":1,"b.2":3,"c.00":1,"d.-1":1}`,
+		`{"a":["x","y"],"b":{"0":1,"2":3},"c":{"00":1},"d":{"-1":1}}`)
+	testUnflatten(t, `{"a\\b":1,"c\\":2,"d\\\\.e":3}`, `{"a\\b":1,"c\\":2,"d\\":{"e":3}}`)
+	testUnflatten(t, `{"a":1,"a":2}`, `{"a":2}`)
+	testUnflatten(t, `{"a.b":{"c.d":1}}`, `{"a":{"b":{"c.d":1}}}`)
+	testUnflatten(t, `[{"a.b":1}]`, `[{"a.b":1}]`)
+}
+func testUnflatten(t *testing.T, input, expected string) {
+	t.Run(input, func(t *testing.T) {
+		nested, err := Unflatten(parse(t, input), ".")
+		should.So(t, err, should.BeNil)
+		should.So(t, render(nested), should.Equal, expected)
+	})
+}
+func TestUnflatten_Conflicts(t *testing.T) {
+	for _, input := range []string{`{"a":1,"a.b":2}`, `{"a.b":2,"a":1}`, `{"a":{},"a.b":2}`} {
+		t.Run(input, func(t *testing.T) {
+			nested, err := Unflatten(parse(t, input), ".")
+			should.So(t, nested, should.BeNil)
+			should.So(t, err, should.WrapError, ErrConflict)
+		})
+	}
+}
+func TestInvalidSeparator(t *testing.T) {
+	for _, separator := range []string{"", `\`, `.\`} {
+		_, err := Flatten(parse(t, `{"a":1}`), separator)
+		should.So(t, err, should.Equal, ErrInvalidSeparator)
+		_, err = Unflatten(parse(t, `{"a":1}`), separator)
+		should.So(t, err, should.Equal, ErrInvalidSeparator)
+	}
+}
+
+func parse(t *testing.T, document string) any {
+	value, err := parsing.ParseBytes([]byte(document))
+	should.So(t, err, should.BeNil)
+	return value
+}
+func render(value any) string {
+	out := &bytes.Buffer{}
+	parsing.Render(value, printing.NewCompactPrinter(out))
+	return out.String()
+}
@@ -0,0 +1,176 @@
+package gron
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/mdwhatcott/coding-challenges.fyi-json/lib/lexing"
+	"github.com/mdwhatcott/coding-challenges.fyi-json/lib/parsing"
+)
+
+var ErrNoStatements = errors.New("no gron statements")
+
+// Ungron rebuilds a document (in the types of parsing.Parse) from gron
+// statements, one per line, such as `json.users[0]["full name"] = "bob";`.
+// Statements may come in any order and may be missing: containers are created
+// as needed, missing array elements are filled in with null (up to MaxIndexGap
+// of them past the end of an array at a time), and assigning an
+// empty container to an existing container of the same kind leaves it intact.
+// The name of the root (`json`) is not checked.
+func Ungron(input io.Reader) (result any, err error) {
+	reader := bufio.NewReader(input)
+	statements := 0
+	for line := 1; ; line++ {
+		text, readErr := reader.ReadString('\n')
+		if readErr != nil && readErr != io.EOF {
+			return nil, readErr
+		}
+		if text = strings.TrimSpace(text); text != "" {
+			path, value, err := parseStatement(text)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", line, err)
+			}
+			result, err = assign(result, path, value)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", line, err)
+			}
+			statements++
+		}
+		if readErr == io.EOF {
+			break
+		}
+	}
+	if statements == 0 {
+		return nil, ErrNoStatements
+	}
+	return result, nil
+}
+
+type segment struct {
+	key   string
+	index int
+	array bool
+}
+
+func parseStatement(text string) (path []segment, value any, err error) {
+	rest := strings.TrimLeftFunc(text, identifier)
+	if len(rest) == len(text) {
+		return nil, nil, fmt.Errorf("missing root name: %q", text)
+	}
+	for !strings.HasPrefix(rest, "=") && !strings.HasPrefix(rest, " ") {
+		var next segment
+		next, rest, err = parseSegment(rest)
+		if err != nil {
+			return nil, nil, err
+		}
+		path = append(path, next)
+	}
+	rest = strings.TrimSpace(rest)
+	if !strings.HasPrefix(rest, "=") {
+		return nil, nil, fmt.Errorf("missing '=': %q", text)
+	}
+	rest = strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(rest[1:]), ";"))
+	value, err = parsing.ParseBytes([]byte(rest))
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid value %q: %w", rest, err)
+	}
+	return path, value, nil
+}
+func parseSegment(text string) (result segment, rest string, err error) {
+	switch {
+	case strings.HasPrefix(text, "."):
+		rest = strings.TrimLeftFunc(text[1:], identifier)
+		if len(rest) == len(text)-1 {
+			return result, "", fmt.Errorf("missing key after '.': %q", text)
+		}
+		result.key = text[1 : len(text)-len(rest)]
+		return result, rest, nil
+	case strings.HasPrefix(text, `["`):
+		end := closingQuote(text[1:]) + 1
+		if end == 0 || !strings.HasPrefix(text[end+1:], "]") {
+			return result, "", fmt.Errorf("unterminated key: %q", text)
+		}
+		result.key = lexing.Unquote([]byte(text[1 : end+1]))
+		return result, text[end+2:], nil
+	case strings.HasPrefix(text, "["):
+		end := strings.IndexByte(text, ']')
+		if end < 0 {
+			return result, "", fmt.Errorf("unterminated index: %q", text)
+		}
+		index, err := strconv.Atoi(text[1:end])
+		if err != nil || index < 0 {
+			return result, "", fmt.Errorf("invalid index: %q", text[:end+1])
+		}
+		return segment{index: index, array: true}, text[end+1:], nil
+	default:
+		return result, "", fmt.Errorf("unexpected path text: %q", text)
+	}
+}
+
+// closingQuote returns the index of the quote that ends the string literal
+// at the start of text, or -1.
+func closingQuote(text string) int {
+	for x := 1; x < len(text); x++ {
+		switch text[x] {
+		case '\\':
+			x++
+		case '"':
+			return x
+		}
+	}
+	return -1
+}
+func identifier(c rune) bool {
+	return unicode.IsLetter(c) || unicode.IsDigit(c) || c == '_' || c == '$'
+}
+
+// MaxIndexGap limits how far past the end of an array a statement may assign
+// an element, so that a single statement can't demand (say) 10^11 nulls.
+const MaxIndexGap = 1000
+
+func assign(target any, path []segment, value any) (any, error) {
+	if len(path) == 0 {
+		if sameEmptyKind(target, value) {
+			return target, nil
+		}
+		return value, nil
+	}
+	if next := path[0]; next.array {
+		array, _ := target.([]any)
+		if next.index > len(array)+MaxIndexGap {
+			return nil, fmt.Errorf("index %d is too far past the end of the array (length %d)", next.index, len(array))
+		}
+		for len(array) <= next.index {
+			array = append(array, nil)
+		}
+		element, err := assign(array[next.index], path[1:], value)
+		array[next.index] = element
+		return array, err
+	}
+	object, ok := target.(*parsing.Object)
+	if !ok {
+		object = &parsing.Object{}
+	}
+	current, _ := object.Get(path[0].key)
+	member, err := assign(current, path[1:], value)
+	object.Set(path[0].key, member)
+	return object, err
+}
+
+// sameEmptyKind reports whether value is an empty container of the same kind as target.
+func sameEmptyKind(target, value any) bool {
+	switch value := value.(type) {
+	case []any:
+		_, ok := target.([]any)
+		return ok && len(value) == 0
+	case *parsing.Object:
+		_, ok := target.(*parsing.Object)
+		return ok && value.Len() == 0
+	}
+	return false
+}
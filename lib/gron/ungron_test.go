@@ -0,0 +1,80 @@
+package gron
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/mdwhatcott/coding-challenges.fyi-json/lib/parsing"
+	"github.com/mdwhatcott/coding-challenges.fyi-json/lib/printing"
+	"github.com/mdwhatcott/testing/should"
+)
+
+func TestUngron_RoundTrip(t *testing.T) {
+	for _, document := range []string{
+		`null`,
+		`"hi"`,
+		`[]`,
+		`{}`,
+		`{"users":[{"name":"bob","admin":false},null],"a.b":{},"$id":1e3,"ünï":"\"x\"","2x":true,"":[[],[{}]]}`,
+		`{"a = b;":"c = d;","[\"]":["]"]}`,
+	} {
+		t.Run(document, func(t *testing.T) {
+			statements := &bytes.Buffer{}
+			parsing.Render(parse(t, document), printing.NewGronPrinter(statements))
+			actual, err := Ungron(statements)
+			should.So(t, err, should.BeNil)
+			should.So(t, render(actual), should.Equal, document)
+		})
+	}
+}
+func TestUngron_PartialStatements(t *testing.T) {
+	testUngron(t, `json.users[1].name = "bob";`, `{"users":[null,{"name":"bob"}]}`)
+	testUngron(t, "json.a.b = 1;\njson.a = {};\n\njson.a.c = 2;\n", `{"a":{"b":1,"c":2}}`)
+	testUngron(t, "json.a = [1];\njson.a = [];", `{"a":[1]}`)
+	testUngron(t, "json.a = 1;\njson.a = {};", `{"a":{}}`)
+	testUngron(t, "json.a = 1;\njson.a.b = 2;", `{"a":{"b":2}}`)
+	testUngron(t, `  json["x"]["y z"][0]  =  "v"  ;  `, `{"x":{"y z":["v"]}}`)
+	testUngron(t, `json = 1`, `1`)
+	testUngron(t, `json[1000] = 1;`, `[`+strings.Repeat(`null,`, 1000)+`1]`)
+}
+func testUngron(t *testing.T, input, expected string) {
+	t.Run(input, func(t *testing.T) {
+		actual, err := Ungron(strings.NewReader(input))
+		should.So(t, err, should.BeNil)
+		should.So(t, render(actual), should.Equal, expected)
+	})
+}
+func TestUngron_Errors(t *testing.T) {
+	testUngronError(t, ``, "no gron statements")
+	testUngronError(t, "\n  \n", "no gron statements")
+	testUngronError(t, `= 1;`, `line 1: missing root name: "= 1;"`)
+	testUngronError(t, "json = 1;\njson.a 1;", `line 2: missing '=': "json.a 1;"`)
+	testUngronError(t, `json. = 1;`, `line 1: missing key after '.': ". = 1;"`)
+	testUngronError(t, `json["a] = 1;`, `line 1: unterminated key: "[\"a] = 1;"`)
+	testUngronError(t, `json[1 = 1;`, `line 1: unterminated index: "[1 = 1;"`)
+	testUngronError(t, `json[-1] = 1;`, `line 1: invalid index: "[-1]"`)
+	testUngronError(t, `json[99999999999] = 1;`, `line 1: index 99999999999 is too far past the end of the array (length 0)`)
+	testUngronError(t, "json.a[0] = 1;\njson.a[1002] = 2;", `line 2: index 1002 is too far past the end of the array (length 1)`)
+	testUngronError(t, `json{} = 1;`, `line 1: unexpected path text: "{} = 1;"`)
+	testUngronError(t, `json.a = nope;`, `line 1: invalid value "nope": `)
+}
+func testUngronError(t *testing.T, input, expected string) {
+	t.Run(input, func(t *testing.T) {
+		actual, err := Ungron(strings.NewReader(input))
+		should.So(t, actual, should.BeNil)
+		should.So(t, err, should.NOT.BeNil)
+		should.So(t, strings.HasPrefix(err.Error(), expected), should.BeTrue)
+	})
+}
+
+func parse(t *testing.T, document string) any {
+	value, err := parsing.ParseBytes([]byte(document))
+	should.So(t, err, should.BeNil)
+	return value
+}
+func render(value any) string {
+	out := &bytes.Buffer{}
+	parsing.Render(value, printing.NewCompactPrinter(out))
+	return out.String()
+}
@@ -0,0 +1,167 @@
+package hashing
+
+import (
+	"bytes"
+	"crypto/md5"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"fmt"
+	"hash"
+	"io"
+	"slices"
+	"strconv"
+	"unicode/utf16"
+
+	"github.com/mdwhatcott/coding-challenges.fyi-json/lib/lexing"
+	"github.com/mdwhatcott/coding-challenges.fyi-json/lib/parsing"
+	"github.com/mdwhatcott/coding-challenges.fyi-json/lib/pointer"
+	"github.com/mdwhatcott/coding-challenges.fyi-json/lib/printing"
+)
+
+// Algorithms maps the names accepted by LookupAlgorithm to their constructors.
+var Algorithms = map[string]func() hash.Hash{
+	"md5":        md5.New,
+	"sha1":       sha1.New,
+	"sha224":     sha256.New224,
+	"sha256":     sha256.New,
+	"sha384":     sha512.New384,
+	"sha512":     sha512.New,
+	"sha512/224": sha512.New512_224,
+	"sha512/256": sha512.New512_256,
+}
+
+func LookupAlgorithm(name string) (func() hash.Hash, bool) {
+	algorithm, ok := Algorithms[name]
+	return algorithm, ok
+}
+func AlgorithmNames() (names []string) {
+	for name := range Algorithms {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// Parse parses a document to hash, failing when it has no canonical form (see
+// printing.NewCanonicalPrinter). Unlike the parsed strings, which decode lone
+// surrogates as U+FFFD, the tokens still tell them apart, so documents with
+// lone surrogates should be parsed here rather than by parsing.Parse.
+func Parse(source io.Reader) (any, error) {
+	input, err := io.ReadAll(source)
+	if err != nil {
+		return nil, err
+	}
+	document, err := parsing.ParseBytes(input)
+	if err != nil {
+		return nil, err
+	}
+	printer := printing.NewCanonicalPrinter(io.Discard)
+	for token := range lexing.Lex(bytes.NewReader(input)) {
+		printer.Print(token)
+	}
+	if err := printer.Err(); err != nil {
+		return nil, err
+	}
+	return document, nil
+}
+
+// Sum hashes the canonical (RFC 8785) form of value, so documents that differ
+// only in formatting, member order or number notation have the same sum.
+// Values with no canonical form (see printing.NewCanonicalPrinter) can't be hashed.
+func Sum(value any, algorithm func() hash.Hash) ([]byte, error) {
+	digest := algorithm()
+	printer := printing.NewCanonicalPrinter(digest)
+	parsing.Render(value, printer)
+	if err := printer.Err(); err != nil {
+		return nil, err
+	}
+	return digest.Sum(nil), nil
+}
+
+type Subtree struct {
+	Path pointer.Pointer
+	Sum  []byte
+}
+
+// Subtrees hashes every value in the document (including the document itself),
+// listing them in document order, parents before their children. The canonical
+// form of each array and object is assembled from those of its children, so
+// each value is canonicalized once.
+func Subtrees(value any, algorithm func() hash.Hash) (subtrees []Subtree, err error) {
+	var visit func(path pointer.Pointer, value any) ([]byte, error)
+	visit = func(path pointer.Pointer, value any) (text []byte, err error) {
+		x := len(subtrees)
+		subtrees = append(subtrees, Subtree{Path: path})
+		switch value := value.(type) {
+		case []any:
+			text = append(text, '[')
+			for y, element := range value {
+				child, err := visit(append(path[:len(path):len(path)], strconv.Itoa(y)), element)
+				if err != nil {
+					return nil, err
+				}
+				if y > 0 {
+					text = append(text, ',')
+				}
+				text = append(text, child...)
+			}
+			text = append(text, ']')
+		case *parsing.Object:
+			members := make([]canonicalMember, len(value.Members))
+			for y, member := range value.Members {
+				child, err := visit(append(path[:len(path):len(path)], member.Key), member.Value)
+				if err != nil {
+					return nil, err
+				}
+				members[y] = canonicalMember{key: member.Key, sortKey: utf16.Encode([]rune(member.Key)), text: child}
+			}
+			if text, err = canonicalObject(members); err != nil {
+				return nil, err
+			}
+		default:
+			buffer := &bytes.Buffer{}
+			printer := printing.NewCanonicalPrinter(buffer)
+			parsing.Render(value, printer)
+			if err := printer.Err(); err != nil {
+				return nil, err
+			}
+			text = buffer.Bytes()
+		}
+		digest := algorithm()
+		_, _ = digest.Write(text)
+		subtrees[x].Sum = digest.Sum(nil)
+		return text, nil
+	}
+	if _, err = visit(pointer.Pointer{}, value); err != nil {
+		return nil, err
+	}
+	return subtrees, nil
+}
+
+type canonicalMember struct {
+	key     string
+	sortKey []uint16
+	text    []byte
+}
+
+// canonicalObject sorts the members (with their canonical values) by the
+// UTF-16 code units of their keys, as printing.NewCanonicalPrinter does.
+func canonicalObject(members []canonicalMember) ([]byte, error) {
+	slices.SortStableFunc(members, func(a, b canonicalMember) int {
+		return slices.Compare(a.sortKey, b.sortKey)
+	})
+	text := []byte{'{'}
+	for x, member := range members {
+		if x > 0 {
+			if member.key == members[x-1].key {
+				return nil, fmt.Errorf("can't canonicalize: %w: %q", printing.ErrDuplicateKey, member.key)
+			}
+			text = append(text, ',')
+		}
+		text = append(text, lexing.Quote(member.key)...)
+		text = append(text, ':')
+		text = append(text, member.text...)
+	}
+	return append(text, '}'), nil
+}
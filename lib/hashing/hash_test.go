@@ -0,0 +1,104 @@
+package hashing
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/mdwhatcott/coding-challenges.fyi-json/lib/parsing"
+	"github.com/mdwhatcott/coding-challenges.fyi-json/lib/pointer"
+	"github.com/mdwhatcott/coding-challenges.fyi-json/lib/printing"
+	"github.com/mdwhatcott/testing/should"
+)
+
+func TestSum(t *testing.T) {
+	a := parse(t, `{"b": [1.0, "é"], "a": null}`)
+	b := parse(t, "{\n  \"a\": null,\n  \"b\": [1e0, \"é\"]\n}")
+	expected := sha256.Sum256([]byte(`{"a":null,"b":[1,"é"]}`))
+	should.So(t, sum(t, a), should.Equal, expected[:])
+	should.So(t, sum(t, b), should.Equal, expected[:])
+	should.So(t, sum(t, parse(t, `{"a": null, "b": [1, "e"]}`)), should.NOT.Equal, expected[:])
+}
+func sum(t *testing.T, value any) []byte {
+	sum, err := Sum(value, sha256.New)
+	should.So(t, err, should.BeNil)
+	return sum
+}
+func TestSum_NoCanonicalForm(t *testing.T) {
+	_, err := Sum(parse(t, `[1e400]`), sha256.New)
+	should.So(t, err, should.WrapError, printing.ErrNumberOutOfRange)
+	_, err = Sum(parse(t, `{"a": 1, "a": 2}`), sha256.New)
+	should.So(t, err, should.WrapError, printing.ErrDuplicateKey)
+	_, err = Subtrees(parse(t, `{"a": [1e400]}`), sha256.New)
+	should.So(t, err, should.WrapError, printing.ErrNumberOutOfRange)
+}
+func TestParse(t *testing.T) {
+	document, err := Parse(strings.NewReader(`["\uFFFD"]`))
+	should.So(t, err, should.BeNil)
+	should.So(t, document, should.Equal, []any{"\uFFFD"})
+	_, err = Parse(strings.NewReader(`["\uD800"]`))
+	should.So(t, err, should.WrapError, printing.ErrLoneSurrogate)
+	_, err = Parse(strings.NewReader(`[1e-400]`))
+	should.So(t, err, should.WrapError, printing.ErrNumberOutOfRange)
+	_, err = Parse(strings.NewReader(`[1,`))
+	should.So(t, err, should.NOT.BeNil)
+}
+func TestSubtrees(t *testing.T) {
+	subtrees, err := Subtrees(parse(t, `{"a": [true], "b": {}}`), sha256.New)
+	should.So(t, err, should.BeNil)
+	var paths []string
+	for _, subtree := range subtrees {
+		paths = append(paths, subtree.Path.String())
+	}
+	should.So(t, paths, should.Equal, []string{"", "/a", "/a/0", "/b"})
+	should.So(t, subtrees[2].Path, should.Equal, pointer.Pointer{"a", "0"})
+	should.So(t, hex.EncodeToString(subtrees[2].Sum), should.Equal, "b5bea41b6c623f7c09f1bf24dcae58ebab3c0cdd90ad966bc43a45b44867e12b")
+}
+func TestSubtrees_MatchSum(t *testing.T) {
+	document := parse(t, `{"b": [1.0, {"\ud83d\ude00": "x", "\uff61": [], "a\u0000": 1e21}], "a": {"c": null, "b": "\u00e9\n"}}`)
+	subtrees, err := Subtrees(document, sha256.New)
+	should.So(t, err, should.BeNil)
+	values := values(document)
+	should.So(t, len(subtrees), should.Equal, len(values))
+	for x, subtree := range subtrees {
+		should.So(t, subtree.Sum, should.Equal, sum(t, values[x]))
+	}
+	_, err = Subtrees(parse(t, `[{"b": 1, "a": 2, "b": 3}]`), sha256.New)
+	should.So(t, err, should.WrapError, printing.ErrDuplicateKey)
+}
+
+// values lists every value in the document in document order, parents before their children.
+func values(document any) []any {
+	result := []any{document}
+	switch document := document.(type) {
+	case []any:
+		for _, element := range document {
+			result = append(result, values(element)...)
+		}
+	case *parsing.Object:
+		for _, member := range document.Members {
+			result = append(result, values(member.Value)...)
+		}
+	}
+	return result
+}
+func TestLookupAlgorithm(t *testing.T) {
+	should.So(t, AlgorithmNames(), should.Equal, []string{"md5", "sha1", "sha224", "sha256", "sha384", "sha512", "sha512/224", "sha512/256"})
+	for _, name := range AlgorithmNames() {
+		algorithm, ok := LookupAlgorithm(name)
+		should.So(t, ok, should.BeTrue)
+		sum, err := Sum(nil, algorithm)
+		should.So(t, err, should.BeNil)
+		should.So(t, sum, should.NOT.BeEmpty)
+	}
+	_, ok := LookupAlgorithm("crc32")
+	should.So(t, ok, should.BeFalse)
+}
+func parse(t *testing.T, input string) any {
+	value, err := parsing.ParseBytes([]byte(input))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return value
+}
@@ -0,0 +1,131 @@
+package jq
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/mdwhatcott/coding-challenges.fyi-json/lib/parsing"
+)
+
+type builtin func(input any, arguments []filter) ([]any, error)
+
+// builtins lists the supported jq functions by name/arity.
+var builtins = map[string]builtin{
+	"empty/0": func(any, []filter) ([]any, error) { return nil, nil },
+	"not/0":   func(input any, _ []filter) ([]any, error) { return []any{!truthy(input)}, nil },
+	"length/0": func(input any, _ []filter) ([]any, error) {
+		value, err := length(input)
+		if err != nil {
+			return nil, err
+		}
+		return []any{value}, nil
+	},
+	"keys/0": func(input any, _ []filter) ([]any, error) {
+		switch input := input.(type) {
+		case *parsing.Object:
+			keys := []any{}
+			for _, key := range sortedKeys(input) {
+				keys = append(keys, key)
+			}
+			return []any{keys}, nil
+		case []any:
+			keys := []any{}
+			for x := range input {
+				keys = append(keys, numberOf(float64(x)))
+			}
+			return []any{keys}, nil
+		default:
+			return nil, fmt.Errorf("%s has no keys", typeName(input))
+		}
+	},
+	"has/1": func(input any, arguments []filter) (output []any, err error) {
+		return each(input, arguments[0], func(key any) (any, error) {
+			switch input := input.(type) {
+			case *parsing.Object:
+				if key, ok := key.(string); ok {
+					_, found := input.Get(key)
+					return found, nil
+				}
+			case []any:
+				if key, ok := key.(parsing.Number); ok {
+					position := key.Float64()
+					return position >= 0 && position < float64(len(input)), nil
+				}
+			}
+			return nil, fmt.Errorf("cannot check whether %s has a %s key", typeName(input), typeName(key))
+		})
+	},
+	"type/0": func(input any, _ []filter) ([]any, error) { return []any{typeName(input)}, nil },
+	"select/1": func(input any, arguments []filter) (output []any, err error) {
+		conditions, err := arguments[0].apply(input)
+		for _, condition := range conditions {
+			if truthy(condition) {
+				output = append(output, input)
+			}
+		}
+		return output, err
+	},
+	"map/1": func(input any, arguments []filter) ([]any, error) {
+		return arrayConstruction{inner: pipe{left: iterate{target: identity{}}, right: arguments[0]}}.apply(input)
+	},
+	"add/0": func(input any, _ []filter) ([]any, error) {
+		values, err := iterate{target: identity{}}.apply(input)
+		if err != nil {
+			return nil, err
+		}
+		var sum any
+		for _, value := range values {
+			if sum, err = add(sum, value); err != nil {
+				return nil, err
+			}
+		}
+		return []any{sum}, nil
+	},
+	"sort/0": func(input any, _ []filter) ([]any, error) {
+		array, ok := input.([]any)
+		if !ok {
+			return nil, fmt.Errorf("%s cannot be sorted, as it is not an array", typeName(input))
+		}
+		sorted := slices.Clone(array)
+		slices.SortStableFunc(sorted, compare)
+		return []any{sorted}, nil
+	},
+	"to_entries/0": func(input any, _ []filter) ([]any, error) {
+		object, ok := input.(*parsing.Object)
+		if !ok {
+			return nil, fmt.Errorf("%s has no entries", typeName(input))
+		}
+		entries := []any{}
+		for _, member := range object.Members {
+			entries = append(entries, &parsing.Object{Members: []parsing.Member{
+				{Key: "key", Value: member.Key},
+				{Key: "value", Value: member.Value},
+			}})
+		}
+		return []any{entries}, nil
+	},
+}
+
+func each(input any, argument filter, function func(any) (any, error)) (output []any, err error) {
+	values, err := argument.apply(input)
+	if err != nil {
+		return nil, err
+	}
+	for _, value := range values {
+		result, err := function(value)
+		if err != nil {
+			return output, err
+		}
+		output = append(output, result)
+	}
+	return output, nil
+}
+
+type call struct {
+	builtin   builtin
+	arguments []filter
+}
+
+func (this call) apply(input any) ([]any, error) {
+	return this.builtin(input, this.arguments)
+}
@@ -0,0 +1,424 @@
+package jq
+
+import (
+	"fmt"
+	"unicode/utf8"
+
+	"github.com/mdwhatcott/coding-challenges.fyi-json/lib/parsing"
+)
+
+// filter maps one input value to a stream of output values.
+type filter interface {
+	apply(input any) ([]any, error)
+}
+
+type identity struct{}
+
+func (identity) apply(input any) ([]any, error) {
+	return []any{input}, nil
+}
+
+type recurse struct{}
+
+func (recurse) apply(input any) (output []any, err error) {
+	output = append(output, input)
+	switch input := input.(type) {
+	case []any:
+		for _, element := range input {
+			descendants, _ := recurse{}.apply(element)
+			output = append(output, descendants...)
+		}
+	case *parsing.Object:
+		for _, member := range input.Members {
+			descendants, _ := recurse{}.apply(member.Value)
+			output = append(output, descendants...)
+		}
+	}
+	return output, nil
+}
+
+type literal struct{ value any }
+
+func (this literal) apply(any) ([]any, error) {
+	return []any{this.value}, nil
+}
+
+type pipe struct{ left, right filter }
+
+func (this pipe) apply(input any) (output []any, err error) {
+	lefts, err := this.left.apply(input)
+	if err != nil {
+		return nil, err
+	}
+	for _, left := range lefts {
+		rights, err := this.right.apply(left)
+		output = append(output, rights...)
+		if err != nil {
+			return output, err
+		}
+	}
+	return output, nil
+}
+
+type comma struct{ left, right filter }
+
+func (this comma) apply(input any) ([]any, error) {
+	lefts, err := this.left.apply(input)
+	if err != nil {
+		return lefts, err
+	}
+	rights, err := this.right.apply(input)
+	return append(lefts, rights...), err
+}
+
+// optional implements the ? operator, which suppresses errors.
+type optional struct{ inner filter }
+
+func (this optional) apply(input any) ([]any, error) {
+	output, _ := this.inner.apply(input)
+	return output, nil
+}
+
+type index struct {
+	target filter
+	key    filter
+}
+
+func (this index) apply(input any) (output []any, err error) {
+	targets, err := this.target.apply(input)
+	if err != nil {
+		return nil, err
+	}
+	keys, err := this.key.apply(input)
+	if err != nil {
+		return nil, err
+	}
+	for _, target := range targets {
+		for _, key := range keys {
+			value, err := lookup(target, key)
+			if err != nil {
+				return output, err
+			}
+			output = append(output, value)
+		}
+	}
+	return output, nil
+}
+func lookup(target, key any) (any, error) {
+	switch target := target.(type) {
+	case nil:
+		switch key.(type) {
+		case string, parsing.Number, nil:
+			return nil, nil
+		}
+	case *parsing.Object:
+		if key, ok := key.(string); ok {
+			value, _ := target.Get(key)
+			return value, nil
+		}
+	case []any:
+		if key, ok := key.(parsing.Number); ok {
+			position := int(key.Float64())
+			if position < 0 {
+				position += len(target)
+			}
+			if position < 0 || position >= len(target) {
+				return nil, nil
+			}
+			return target[position], nil
+		}
+	}
+	return nil, fmt.Errorf("cannot index %s with %s", typeName(target), typeName(key))
+}
+
+type slice struct {
+	target     filter
+	start, end filter // either may be nil
+}
+
+func (this slice) apply(input any) (output []any, err error) {
+	targets, err := this.target.apply(input)
+	if err != nil {
+		return nil, err
+	}
+	start, err := this.bound(this.start, input)
+	if err != nil {
+		return nil, err
+	}
+	end, err := this.bound(this.end, input)
+	if err != nil {
+		return nil, err
+	}
+	for _, target := range targets {
+		switch target := target.(type) {
+		case nil:
+			output = append(output, nil)
+		case []any:
+			from, to := sliceBounds(start, end, len(target))
+			output = append(output, append([]any{}, target[from:to]...))
+		case string:
+			runes := []rune(target)
+			from, to := sliceBounds(start, end, len(runes))
+			output = append(output, string(runes[from:to]))
+		default:
+			return output, fmt.Errorf("cannot slice %s", typeName(target))
+		}
+	}
+	return output, nil
+}
+func (this slice) bound(bound filter, input any) (*int, error) {
+	if bound == nil {
+		return nil, nil
+	}
+	values, err := bound.apply(input)
+	if err != nil {
+		return nil, err
+	}
+	if len(values) != 1 {
+		return nil, fmt.Errorf("slice bounds must produce exactly one value")
+	}
+	switch value := values[0].(type) {
+	case nil:
+		return nil, nil
+	case parsing.Number:
+		result := int(value.Float64())
+		return &result, nil
+	default:
+		return nil, fmt.Errorf("slice bounds must be numbers, not %s", typeName(value))
+	}
+}
+func sliceBounds(start, end *int, length int) (int, int) {
+	clamp := func(bound *int, fallback int) int {
+		if bound == nil {
+			return fallback
+		}
+		value := *bound
+		if value < 0 {
+			value += length
+		}
+		return min(max(value, 0), length)
+	}
+	from, to := clamp(start, 0), clamp(end, length)
+	return from, max(from, to)
+}
+
+type iterate struct{ target filter }
+
+func (this iterate) apply(input any) (output []any, err error) {
+	targets, err := this.target.apply(input)
+	if err != nil {
+		return nil, err
+	}
+	for _, target := range targets {
+		switch target := target.(type) {
+		case []any:
+			output = append(output, target...)
+		case *parsing.Object:
+			for _, member := range target.Members {
+				output = append(output, member.Value)
+			}
+		default:
+			return output, fmt.Errorf("cannot iterate over %s", typeName(target))
+		}
+	}
+	return output, nil
+}
+
+type arrayConstruction struct{ inner filter } // inner may be nil: []
+
+func (this arrayConstruction) apply(input any) ([]any, error) {
+	if this.inner == nil {
+		return []any{[]any{}}, nil
+	}
+	elements, err := this.inner.apply(input)
+	if err != nil {
+		return nil, err
+	}
+	return []any{append([]any{}, elements...)}, nil
+}
+
+type objectConstruction struct{ entries []objectEntry }
+
+type objectEntry struct{ key, value filter }
+
+func (this objectConstruction) apply(input any) ([]any, error) {
+	partials := []*parsing.Object{{}}
+	for _, entry := range this.entries {
+		keys, err := entry.key.apply(input)
+		if err != nil {
+			return nil, err
+		}
+		values, err := entry.value.apply(input)
+		if err != nil {
+			return nil, err
+		}
+		var next []*parsing.Object
+		for _, partial := range partials {
+			for _, key := range keys {
+				name, ok := key.(string)
+				if !ok {
+					return nil, fmt.Errorf("object keys must be strings, not %s", typeName(key))
+				}
+				for _, value := range values {
+					object := &parsing.Object{Members: append([]parsing.Member{}, partial.Members...)}
+					object.Set(name, value)
+					next = append(next, object)
+				}
+			}
+		}
+		partials = next
+	}
+	output := make([]any, len(partials))
+	for x, partial := range partials {
+		output[x] = partial
+	}
+	return output, nil
+}
+
+type binary struct {
+	operator    string
+	left, right filter
+}
+
+func (this binary) apply(input any) (output []any, err error) {
+	rights, err := this.right.apply(input)
+	if err != nil {
+		return nil, err
+	}
+	lefts, err := this.left.apply(input)
+	if err != nil {
+		return nil, err
+	}
+	for _, right := range rights {
+		for _, left := range lefts {
+			value, err := operate(this.operator, left, right)
+			if err != nil {
+				return output, err
+			}
+			output = append(output, value)
+		}
+	}
+	return output, nil
+}
+
+type and struct{ left, right filter }
+
+func (this and) apply(input any) (output []any, err error) {
+	lefts, err := this.left.apply(input)
+	if err != nil {
+		return nil, err
+	}
+	for _, left := range lefts {
+		if !truthy(left) {
+			output = append(output, false)
+			continue
+		}
+		rights, err := this.right.apply(input)
+		if err != nil {
+			return output, err
+		}
+		for _, right := range rights {
+			output = append(output, truthy(right))
+		}
+	}
+	return output, nil
+}
+
+type or struct{ left, right filter }
+
+func (this or) apply(input any) (output []any, err error) {
+	lefts, err := this.left.apply(input)
+	if err != nil {
+		return nil, err
+	}
+	for _, left := range lefts {
+		if truthy(left) {
+			output = append(output, true)
+			continue
+		}
+		rights, err := this.right.apply(input)
+		if err != nil {
+			return output, err
+		}
+		for _, right := range rights {
+			output = append(output, truthy(right))
+		}
+	}
+	return output, nil
+}
+
+// alternative implements a // b: the truthy outputs of a, or else the outputs of b.
+type alternative struct{ left, right filter }
+
+func (this alternative) apply(input any) (output []any, err error) {
+	lefts, _ := this.left.apply(input)
+	for _, left := range lefts {
+		if truthy(left) {
+			output = append(output, left)
+		}
+	}
+	if len(output) > 0 {
+		return output, nil
+	}
+	return this.right.apply(input)
+}
+
+type negate struct{ inner filter }
+
+func (this negate) apply(input any) (output []any, err error) {
+	values, err := this.inner.apply(input)
+	if err != nil {
+		return nil, err
+	}
+	for _, value := range values {
+		number, ok := value.(parsing.Number)
+		if !ok {
+			return output, fmt.Errorf("cannot negate %s", typeName(value))
+		}
+		output = append(output, numberOf(-number.Float64()))
+	}
+	return output, nil
+}
+
+func truthy(value any) bool {
+	return value != nil && value != false
+}
+
+func typeName(value any) string {
+	switch value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case parsing.Number:
+		return "number"
+	case string:
+		return "string"
+	case []any:
+		return "array"
+	case *parsing.Object:
+		return "object"
+	default:
+		return fmt.Sprintf("%T", value)
+	}
+}
+
+func length(value any) (any, error) {
+	switch value := value.(type) {
+	case nil:
+		return numberOf(0), nil
+	case parsing.Number:
+		if value.Float64() < 0 {
+			return numberOf(-value.Float64()), nil
+		}
+		return value, nil
+	case string:
+		return numberOf(float64(utf8.RuneCountInString(value))), nil
+	case []any:
+		return numberOf(float64(len(value))), nil
+	case *parsing.Object:
+		return numberOf(float64(value.Len())), nil
+	default:
+		return nil, fmt.Errorf("%s has no length", typeName(value))
+	}
+}
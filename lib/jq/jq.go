@@ -0,0 +1,34 @@
+package jq
+
+// Filter is a compiled jq program, applied to documents produced by the parsing package.
+//
+// The supported language is a subset of jq: identity (.), recursion (..),
+// field and index access (.foo, ."foo", .[0], .["foo"], .[1:3]), iteration (.[]),
+// the optional operator (?), pipes (|), commas (,), parentheses, literals,
+// array and object construction, arithmetic (+ - * / %), comparisons
+// (== != < <= > >=), alternatives (//), and/or, and the builtins listed in builtins.go.
+type Filter struct {
+	text string
+	root filter
+}
+
+func Compile(text string) (*Filter, error) {
+	tokens, err := scan(text)
+	if err != nil {
+		return nil, err
+	}
+	root, err := newParser(tokens).program()
+	if err != nil {
+		return nil, err
+	}
+	return &Filter{text: text, root: root}, nil
+}
+
+func (this *Filter) String() string {
+	return this.text
+}
+
+// Apply runs the filter against input, returning all of its outputs.
+func (this *Filter) Apply(input any) ([]any, error) {
+	return this.root.apply(input)
+}
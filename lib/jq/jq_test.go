@@ -0,0 +1,135 @@
+package jq
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/mdwhatcott/coding-challenges.fyi-json/lib/parsing"
+	"github.com/mdwhatcott/coding-challenges.fyi-json/lib/printing"
+	"github.com/mdwhatcott/testing/should"
+)
+
+const document = `{"a": 1, "b": [1, 2, 3], "c": {"d": "x", "e": null}, "users": [
+	{"name": "ann", "age": 31, "admin": true},
+	{"name": "bob", "age": 17},
+	{"name": "cat", "age": 45, "admin": false}
+]}`
+
+func TestPaths(t *testing.T) {
+	testJQ(t, `.`, `{"a":1,"b":[1,2,3],"c":{"d":"x","e":null},"users":[{"name":"ann","age":31,"admin":true},{"name":"bob","age":17},{"name":"cat","age":45,"admin":false}]}`)
+	testJQ(t, `.a`, `1`)
+	testJQ(t, `.c.d`, `"x"`)
+	testJQ(t, `."c"."d"`, `"x"`)
+	testJQ(t, `.["c"]["d"]`, `"x"`)
+	testJQ(t, `.nope`, `null`)
+	testJQ(t, `.nope.deeper`, `null`)
+	testJQ(t, `.b[0]`, `1`)
+	testJQ(t, `.b[-1]`, `3`)
+	testJQ(t, `.b.[1]`, `2`)
+	testJQ(t, `.b[7]`, `null`)
+	testJQ(t, `.b[1:]`, `[2,3]`)
+	testJQ(t, `.b[:-1]`, `[1,2]`)
+	testJQ(t, `.c.d[0:1]`, `"x"`)
+	testJQ(t, `.b[]`, `1`, `2`, `3`)
+	testJQ(t, `.c[]`, `"x"`, `null`)
+	testJQ(t, `.users[].name`, `"ann"`, `"bob"`, `"cat"`)
+	testJQ(t, `.a[]?`)
+	testJQ(t, `.b[0].x?`)
+	testJQ(t, `[.c | ..]`, `[{"d":"x","e":null},"x",null]`)
+}
+func TestPipesAndCommas(t *testing.T) {
+	testJQ(t, `.a, .c.d`, `1`, `"x"`)
+	testJQ(t, `.users[] | .name`, `"ann"`, `"bob"`, `"cat"`)
+	testJQ(t, `.c | .d, .e`, `"x"`, `null`)
+	testJQ(t, `(.a, .b[0]) | . + 10`, `11`, `11`)
+	testJQ(t, `[.b[] | . * 2]`, `[2,4,6]`)
+}
+func TestBuiltins(t *testing.T) {
+	testJQ(t, `.users | map(.name)`, `["ann","bob","cat"]`)
+	testJQ(t, `.users[] | select(.age >= 18) | .name`, `"ann"`, `"cat"`)
+	testJQ(t, `.users[] | select(.admin) | .name`, `"ann"`)
+	testJQ(t, `.users[] | select(.admin | not) | .name`, `"bob"`, `"cat"`)
+	testJQ(t, `.c | keys`, `["d","e"]`)
+	testJQ(t, `.b | keys`, `[0,1,2]`)
+	testJQ(t, `.users[0] | keys`, `["admin","age","name"]`)
+	testJQ(t, `.b, .c, .c.d, .a, .c.e | length`, `3`, `2`, `1`, `1`, `0`)
+	testJQ(t, `.c | has("d"), has("z")`, `true`, `false`)
+	testJQ(t, `.b | has(0), has(3)`, `true`, `false`)
+	testJQ(t, `.a, .b, .c, .c.d, .c.e, true | type`, `"number"`, `"array"`, `"object"`, `"string"`, `"null"`, `"boolean"`)
+	testJQ(t, `.b | add`, `6`)
+	testJQ(t, `[.users[].name] | add`, `"annbobcat"`)
+	testJQ(t, `[3, "a", null, [1], true, {}, 1] | sort`, `[null,true,1,3,"a",[1],{}]`)
+	testJQ(t, `.c | to_entries`, `[{"key":"d","value":"x"},{"key":"e","value":null}]`)
+	testJQ(t, `.b[] | select(. != 2)`, `1`, `3`)
+	testJQ(t, `[.b[] | empty]`, `[]`)
+	testJQ(t, `[..] | length`, `21`)
+}
+func TestConstruction(t *testing.T) {
+	testJQ(t, `[]`, `[]`)
+	testJQ(t, `{}`, `{}`)
+	testJQ(t, `{a}`, `{"a":1}`)
+	testJQ(t, `{x: .a, "y": .c.d, (.c.d): 2}`, `{"x":2,"y":"x"}`)
+	testJQ(t, `{count: .b | length}`, `{"count":3}`)
+	testJQ(t, `{n: .users[].name}`, `{"n":"ann"}`, `{"n":"bob"}`, `{"n":"cat"}`)
+	testJQ(t, `.users | map({name, adult: (.age >= 18)})`,
+		`[{"name":"ann","adult":true},{"name":"bob","adult":false},{"name":"cat","adult":true}]`)
+	testJQ(t, `[1, "two", null, true, false, -1.5]`, `[1,"two",null,true,false,-1.5]`)
+}
+func TestOperators(t *testing.T) {
+	testJQ(t, `1 + 2 * 3 - 4 / 2`, `5`)
+	testJQ(t, `7 % 3, -(1 + 1)`, `1`, `-2`)
+	testJQ(t, `3 / 10, 1 / 3, .1 + .2, 1e300 * 10, .5, [.25]`, `0.3`, `0.3333333333333333`, `0.30000000000000004`, `1e+301`, `0.5`, `[0.25]`)
+	testJQ(t, `"a" + "b", [1] + [2], null + 1, {"a": 1} + {"b": 2}`, `"ab"`, `[1,2]`, `1`, `{"a":1,"b":2}`)
+	testJQ(t, `[1, 2, 3, 2] - [2]`, `[1,3]`)
+	testJQ(t, `(1, 2) + (10, 20)`, `11`, `12`, `21`, `22`)
+	testJQ(t, `1 < 2, "a" > "b", null < false, [1] == [1.0], 2 <= 2, 1 >= 2`, `true`, `false`, `true`, `true`, `true`, `false`)
+	testJQ(t, `true and false, true or false, null or 1, (false, true) and true`, `false`, `true`, `true`, `false`, `true`)
+	testJQ(t, `.nope // "default", .a // 2, (false, null) // 3`, `"default"`, `1`, `3`)
+}
+func TestErrors(t *testing.T) {
+	testError(t, `.a.b`, "cannot index number with string")
+	testError(t, `.b.a`, "cannot index array with string")
+	testError(t, `.a[]`, "cannot iterate over number")
+	testError(t, `.a + "x"`, "number and string cannot be added")
+	testError(t, `1 / 0`, "1 and 0 cannot be divided because the divisor is zero")
+	testError(t, `.c | keys | length | keys`, "number has no keys")
+}
+func TestSyntaxErrors(t *testing.T) {
+	for _, text := range []string{``, `.[`, `.a |`, `{a:}`, `{1: 2}`, `nope`, `map()`, `map(.; .)`, `(.a`, `.a $`, `"abc`, `1 +`, `"\x"`} {
+		t.Run(text, func(t *testing.T) {
+			_, err := Compile(text)
+			should.So(t, err, should.NOT.BeNil)
+		})
+	}
+}
+func testJQ(t *testing.T, program string, expected ...string) {
+	t.Run(program, func(t *testing.T) {
+		outputs, err := run(t, program)
+		should.So(t, err, should.BeNil)
+		should.So(t, outputs, should.Equal, expected)
+	})
+}
+func testError(t *testing.T, program string, expected string) {
+	t.Run(program, func(t *testing.T) {
+		_, err := run(t, program)
+		should.So(t, err, should.NOT.BeNil)
+		should.So(t, err.Error(), should.Equal, expected)
+	})
+}
+func run(t *testing.T, program string) (outputs []string, err error) {
+	filter, err := Compile(program)
+	if err != nil {
+		t.Fatal(err)
+	}
+	input, err := parsing.ParseBytes([]byte(document))
+	if err != nil {
+		t.Fatal(err)
+	}
+	results, err := filter.Apply(input)
+	for _, result := range results {
+		out := &bytes.Buffer{}
+		parsing.Render(result, printing.NewCompactPrinter(out))
+		outputs = append(outputs, out.String())
+	}
+	return outputs, err
+}
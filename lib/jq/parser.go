@@ -0,0 +1,371 @@
+package jq
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/mdwhatcott/coding-challenges.fyi-json/lib/parsing"
+)
+
+type parser struct {
+	tokens []token
+	at     int
+}
+
+func newParser(tokens []token) *parser {
+	return &parser{tokens: tokens}
+}
+
+type SyntaxError struct {
+	Offset int
+	Reason string
+}
+
+func (this *SyntaxError) Error() string {
+	return fmt.Sprintf("jq: syntax error at offset %d: %s", this.Offset, this.Reason)
+}
+
+func (this *parser) fail(format string, args ...any) error {
+	return failAt(this.peek(), format, args...)
+}
+func failAt(token token, format string, args ...any) error {
+	return &SyntaxError{Offset: token.offset, Reason: fmt.Sprintf(format, args...)}
+}
+func (this *parser) peek() token {
+	return this.tokens[this.at]
+}
+func (this *parser) next() token {
+	token := this.tokens[this.at]
+	if token.kind != tokenEOF {
+		this.at++
+	}
+	return token
+}
+func (this *parser) symbol(text string) bool {
+	if token := this.peek(); token.kind == tokenSymbol && token.text == text {
+		this.at++
+		return true
+	}
+	return false
+}
+func (this *parser) keyword(text string) bool {
+	if token := this.peek(); token.kind == tokenIdent && token.text == text {
+		this.at++
+		return true
+	}
+	return false
+}
+func (this *parser) expect(text string) error {
+	if !this.symbol(text) {
+		return this.fail("expected '%s'", text)
+	}
+	return nil
+}
+
+func (this *parser) program() (filter, error) {
+	result, err := this.pipe()
+	if err != nil {
+		return nil, err
+	}
+	if this.peek().kind != tokenEOF {
+		return nil, this.fail("unexpected '%s'", this.peek().text)
+	}
+	return result, nil
+}
+
+// pipe := comma ('|' pipe)?
+func (this *parser) pipe() (filter, error) {
+	left, err := this.comma()
+	if err != nil || !this.symbol("|") {
+		return left, err
+	}
+	right, err := this.pipe()
+	if err != nil {
+		return nil, err
+	}
+	return pipe{left: left, right: right}, nil
+}
+
+// comma := alternative (',' alternative)*
+func (this *parser) comma() (filter, error) {
+	left, err := this.alternative()
+	for err == nil && this.symbol(",") {
+		var right filter
+		right, err = this.alternative()
+		left = comma{left: left, right: right}
+	}
+	return left, err
+}
+
+// alternative := or ('//' alternative)?
+func (this *parser) alternative() (filter, error) {
+	left, err := this.or()
+	if err != nil || !this.symbol("//") {
+		return left, err
+	}
+	right, err := this.alternative()
+	return alternative{left: left, right: right}, err
+}
+
+// or := and ('or' and)*
+func (this *parser) or() (filter, error) {
+	left, err := this.and()
+	for err == nil && this.keyword("or") {
+		var right filter
+		right, err = this.and()
+		left = or{left: left, right: right}
+	}
+	return left, err
+}
+
+// and := comparison ('and' comparison)*
+func (this *parser) and() (filter, error) {
+	left, err := this.comparison()
+	for err == nil && this.keyword("and") {
+		var right filter
+		right, err = this.comparison()
+		left = and{left: left, right: right}
+	}
+	return left, err
+}
+
+// comparison := additive (operator additive)?
+func (this *parser) comparison() (filter, error) {
+	left, err := this.additive()
+	if err != nil {
+		return nil, err
+	}
+	for _, operator := range []string{"==", "!=", "<=", ">=", "<", ">"} {
+		if this.symbol(operator) {
+			right, err := this.additive()
+			return binary{operator: operator, left: left, right: right}, err
+		}
+	}
+	return left, nil
+}
+
+// additive := multiplicative (('+'|'-') multiplicative)*
+func (this *parser) additive() (filter, error) {
+	return this.binaryLevel(this.multiplicative, "+", "-")
+}
+
+// multiplicative := unary (('*'|'/'|'%') unary)*
+func (this *parser) multiplicative() (filter, error) {
+	return this.binaryLevel(this.unary, "*", "/", "%")
+}
+func (this *parser) binaryLevel(operand func() (filter, error), operators ...string) (filter, error) {
+	left, err := operand()
+	for err == nil {
+		operator := ""
+		for _, candidate := range operators {
+			if this.symbol(candidate) {
+				operator = candidate
+				break
+			}
+		}
+		if operator == "" {
+			break
+		}
+		var right filter
+		right, err = operand()
+		left = binary{operator: operator, left: left, right: right}
+	}
+	return left, err
+}
+
+// unary := '-' unary | postfix
+func (this *parser) unary() (filter, error) {
+	if this.symbol("-") {
+		inner, err := this.unary()
+		return negate{inner: inner}, err
+	}
+	return this.postfix()
+}
+
+// postfix := primary (field | '[' ... ']' | '.' '[' ... ']' | '?')*
+func (this *parser) postfix() (result filter, err error) {
+	result, err = this.primary()
+	for err == nil {
+		switch token := this.peek(); {
+		case token.kind == tokenField:
+			this.next()
+			result = index{target: result, key: literal{value: token.text}}
+		case token.kind == tokenDot && this.tokens[this.at+1].text == "[":
+			this.next()
+		case token.kind == tokenSymbol && token.text == "[":
+			this.next()
+			result, err = this.bracketSuffix(result)
+		case token.kind == tokenSymbol && token.text == "?":
+			this.next()
+			result = optional{inner: result}
+		default:
+			return result, nil
+		}
+	}
+	return nil, err
+}
+
+// bracketSuffix parses what follows '[' after a term: ']', key ']', or start? ':' end? ']'.
+func (this *parser) bracketSuffix(target filter) (filter, error) {
+	if this.symbol("]") {
+		return iterate{target: target}, nil
+	}
+	var start, end filter
+	var err error
+	if !this.symbol(":") {
+		if start, err = this.pipe(); err != nil {
+			return nil, err
+		}
+		if this.symbol("]") {
+			return index{target: target, key: start}, nil
+		}
+		if err = this.expect(":"); err != nil {
+			return nil, err
+		}
+	}
+	if !this.symbol("]") {
+		if end, err = this.pipe(); err != nil {
+			return nil, err
+		}
+		if err = this.expect("]"); err != nil {
+			return nil, err
+		}
+	}
+	return slice{target: target, start: start, end: end}, nil
+}
+
+func (this *parser) primary() (filter, error) {
+	token := this.next()
+	switch token.kind {
+	case tokenDot:
+		return identity{}, nil
+	case tokenRecurse:
+		return recurse{}, nil
+	case tokenField:
+		return index{target: identity{}, key: literal{value: token.text}}, nil
+	case tokenString:
+		return literal{value: token.text}, nil
+	case tokenNumber:
+		value, err := strconv.ParseFloat(token.text, 64)
+		if err != nil {
+			return nil, failAt(token, "invalid number '%s'", token.text)
+		}
+		if strings.HasPrefix(token.text, ".") {
+			return literal{value: numberOf(value)}, nil
+		}
+		return literal{value: parsing.Number(token.text)}, nil
+	case tokenIdent:
+		return this.identifier(token)
+	case tokenSymbol:
+		switch token.text {
+		case "(":
+			inner, err := this.pipe()
+			if err != nil {
+				return nil, err
+			}
+			return inner, this.expect(")")
+		case "[":
+			if this.symbol("]") {
+				return arrayConstruction{}, nil
+			}
+			inner, err := this.pipe()
+			if err != nil {
+				return nil, err
+			}
+			return arrayConstruction{inner: inner}, this.expect("]")
+		case "{":
+			return this.object()
+		}
+	}
+	if token.kind == tokenEOF {
+		return nil, failAt(token, "unexpected end of program")
+	}
+	return nil, failAt(token, "unexpected '%s'", token.text)
+}
+func (this *parser) identifier(token token) (filter, error) {
+	switch token.text {
+	case "true":
+		return literal{value: true}, nil
+	case "false":
+		return literal{value: false}, nil
+	case "null":
+		return literal{value: nil}, nil
+	}
+	var arguments []filter
+	if this.symbol("(") {
+		for {
+			argument, err := this.pipe()
+			if err != nil {
+				return nil, err
+			}
+			arguments = append(arguments, argument)
+			if this.symbol(")") {
+				break
+			}
+			if err = this.expect(";"); err != nil {
+				return nil, err
+			}
+		}
+	}
+	function, ok := builtins[fmt.Sprintf("%s/%d", token.text, len(arguments))]
+	if !ok {
+		return nil, failAt(token, "%s/%d is not defined", token.text, len(arguments))
+	}
+	return call{builtin: function, arguments: arguments}, nil
+}
+
+// object := '{' (entry (',' entry)*)? '}'
+// entry  := (ident | string | '(' pipe ')') (':' value)?
+func (this *parser) object() (filter, error) {
+	var result objectConstruction
+	if this.symbol("}") {
+		return result, nil
+	}
+	for {
+		var entry objectEntry
+		token := this.next()
+		switch {
+		case token.kind == tokenIdent || token.kind == tokenString:
+			entry.key = literal{value: token.text}
+			entry.value = index{target: identity{}, key: literal{value: token.text}}
+		case token.kind == tokenSymbol && token.text == "(":
+			key, err := this.pipe()
+			if err != nil {
+				return nil, err
+			}
+			if err = this.expect(")"); err != nil {
+				return nil, err
+			}
+			entry.key = key
+		default:
+			return nil, failAt(token, "invalid object key")
+		}
+		if this.symbol(":") {
+			value, err := this.objectValue()
+			if err != nil {
+				return nil, err
+			}
+			entry.value = value
+		} else if entry.value == nil {
+			return nil, this.fail("expected ':'")
+		}
+		result.entries = append(result.entries, entry)
+		if this.symbol("}") {
+			return result, nil
+		}
+		if err := this.expect(","); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// objectValue := alternative ('|' objectValue)?, which (unlike pipe) excludes commas.
+func (this *parser) objectValue() (filter, error) {
+	left, err := this.alternative()
+	if err != nil || !this.symbol("|") {
+		return left, err
+	}
+	right, err := this.objectValue()
+	return pipe{left: left, right: right}, err
+}
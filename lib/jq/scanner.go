@@ -0,0 +1,135 @@
+package jq
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mdwhatcott/coding-challenges.fyi-json/lib/lexing"
+)
+
+type tokenKind int
+
+const (
+	tokenEOF     tokenKind = iota
+	tokenDot               // .
+	tokenRecurse           // ..
+	tokenField             // .name or ."name" (text holds the name)
+	tokenIdent             // name, including keywords
+	tokenString            // "text" (text holds the decoded value)
+	tokenNumber            // 1.5
+	tokenSymbol            // punctuation and operators
+)
+
+type token struct {
+	kind   tokenKind
+	text   string
+	offset int
+}
+
+func scan(source string) (tokens []token, err error) {
+	for x := 0; ; {
+		for x < len(source) && strings.IndexByte(" \t\r\n", source[x]) >= 0 {
+			x++
+		}
+		if x >= len(source) {
+			return append(tokens, token{kind: tokenEOF, offset: x}), nil
+		}
+		start := x
+		c := source[x]
+		switch {
+		case c == '.' && x+1 < len(source) && source[x+1] == '.':
+			x += 2
+			tokens = append(tokens, token{kind: tokenRecurse, text: "..", offset: start})
+		case c == '.' && x+1 < len(source) && isIdentStart(source[x+1]):
+			x = scanIdent(source, x+1)
+			tokens = append(tokens, token{kind: tokenField, text: source[start+1 : x], offset: start})
+		case c == '.' && x+1 < len(source) && source[x+1] == '"':
+			text, end, err := scanString(source, x+1)
+			if err != nil {
+				return nil, err
+			}
+			x = end
+			tokens = append(tokens, token{kind: tokenField, text: text, offset: start})
+		case isDigit(c) || (c == '.' && x+1 < len(source) && isDigit(source[x+1])): // (jq reads .5 as 0.5)
+			for x < len(source) && (isDigit(source[x]) || source[x] == '.') {
+				x++
+			}
+			if x < len(source) && (source[x] == 'e' || source[x] == 'E') {
+				x++
+				if x < len(source) && (source[x] == '+' || source[x] == '-') {
+					x++
+				}
+				for x < len(source) && isDigit(source[x]) {
+					x++
+				}
+			}
+			tokens = append(tokens, token{kind: tokenNumber, text: source[start:x], offset: start})
+		case c == '.':
+			x++
+			tokens = append(tokens, token{kind: tokenDot, text: ".", offset: start})
+		case c == '"':
+			text, end, err := scanString(source, x)
+			if err != nil {
+				return nil, err
+			}
+			x = end
+			tokens = append(tokens, token{kind: tokenString, text: text, offset: start})
+		case isIdentStart(c):
+			x = scanIdent(source, x)
+			tokens = append(tokens, token{kind: tokenIdent, text: source[start:x], offset: start})
+		default:
+			symbol := ""
+			for _, candidate := range symbols {
+				if strings.HasPrefix(source[x:], candidate) {
+					symbol = candidate
+					break
+				}
+			}
+			if symbol == "" {
+				return nil, fmt.Errorf("jq: unexpected character %q at offset %d", c, x)
+			}
+			x += len(symbol)
+			tokens = append(tokens, token{kind: tokenSymbol, text: symbol, offset: start})
+		}
+	}
+}
+
+var symbols = []string{
+	"==", "!=", "<=", ">=", "//",
+	"|", ",", ":", ";", "(", ")", "[", "]", "{", "}", "<", ">", "+", "-", "*", "/", "%", "?",
+}
+
+func scanIdent(source string, x int) int {
+	for x < len(source) && (isIdentStart(source[x]) || isDigit(source[x])) {
+		x++
+	}
+	return x
+}
+func scanString(source string, x int) (text string, end int, err error) {
+	for end = x + 1; end < len(source); end++ {
+		switch source[end] {
+		case '\\':
+			end++
+		case '"':
+			raw := []byte(source[x : end+1])
+			if !isValidString(raw) {
+				return "", 0, fmt.Errorf("jq: invalid string literal at offset %d", x)
+			}
+			return lexing.Unquote(raw), end + 1, nil
+		}
+	}
+	return "", 0, fmt.Errorf("jq: unterminated string literal at offset %d", x)
+}
+func isValidString(raw []byte) bool {
+	var last lexing.Token
+	for token := range lexing.Lex(strings.NewReader(string(raw))) {
+		last = token
+	}
+	return last.Type == lexing.TokenString
+}
+func isIdentStart(c byte) bool {
+	return ('a' <= c && c <= 'z') || ('A' <= c && c <= 'Z') || c == '_'
+}
+func isDigit(c byte) bool {
+	return '0' <= c && c <= '9'
+}
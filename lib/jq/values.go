@@ -0,0 +1,160 @@
+package jq
+
+import (
+	"cmp"
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+
+	"github.com/mdwhatcott/coding-challenges.fyi-json/lib/parsing"
+)
+
+func numberOf(value float64) parsing.Number {
+	if value == math.Trunc(value) && math.Abs(value) < 1e17 {
+		return parsing.Number(strconv.FormatFloat(value, 'f', -1, 64))
+	}
+	return parsing.Number(strconv.FormatFloat(value, 'g', -1, 64))
+}
+
+func operate(operator string, left, right any) (any, error) {
+	switch operator {
+	case "==":
+		return parsing.Equal(left, right), nil
+	case "!=":
+		return !parsing.Equal(left, right), nil
+	case "<":
+		return compare(left, right) < 0, nil
+	case "<=":
+		return compare(left, right) <= 0, nil
+	case ">":
+		return compare(left, right) > 0, nil
+	case ">=":
+		return compare(left, right) >= 0, nil
+	case "+":
+		return add(left, right)
+	}
+	a, aOK := left.(parsing.Number)
+	b, bOK := right.(parsing.Number)
+	if operator == "-" {
+		if left, ok := left.([]any); ok {
+			if right, ok := right.([]any); ok {
+				return subtract(left, right), nil
+			}
+		}
+	}
+	if !aOK || !bOK {
+		return nil, fmt.Errorf("%s and %s cannot be used with %s", typeName(left), typeName(right), operator)
+	}
+	switch operator {
+	case "-":
+		return numberOf(a.Float64() - b.Float64()), nil
+	case "*":
+		return numberOf(a.Float64() * b.Float64()), nil
+	case "/":
+		if b.Float64() == 0 {
+			return nil, fmt.Errorf("%s and %s cannot be divided because the divisor is zero", a, b)
+		}
+		return numberOf(a.Float64() / b.Float64()), nil
+	case "%":
+		if int(b.Float64()) == 0 {
+			return nil, fmt.Errorf("%s and %s cannot be divided because the divisor is zero", a, b)
+		}
+		return numberOf(float64(int(a.Float64()) % int(b.Float64()))), nil
+	default:
+		return nil, fmt.Errorf("unknown operator: %s", operator)
+	}
+}
+func add(left, right any) (any, error) {
+	if left == nil {
+		return right, nil
+	}
+	if right == nil {
+		return left, nil
+	}
+	switch left := left.(type) {
+	case parsing.Number:
+		if right, ok := right.(parsing.Number); ok {
+			return numberOf(left.Float64() + right.Float64()), nil
+		}
+	case string:
+		if right, ok := right.(string); ok {
+			return left + right, nil
+		}
+	case []any:
+		if right, ok := right.([]any); ok {
+			return append(slices.Clip(left), right...), nil
+		}
+	case *parsing.Object:
+		if right, ok := right.(*parsing.Object); ok {
+			result := &parsing.Object{Members: slices.Clone(left.Members)}
+			for _, member := range right.Members {
+				result.Set(member.Key, member.Value)
+			}
+			return result, nil
+		}
+	}
+	return nil, fmt.Errorf("%s and %s cannot be added", typeName(left), typeName(right))
+}
+func subtract(left, right []any) (result []any) {
+	result = []any{}
+	for _, element := range left {
+		if !slices.ContainsFunc(right, func(other any) bool { return parsing.Equal(element, other) }) {
+			result = append(result, element)
+		}
+	}
+	return result
+}
+
+// compare orders values as jq does: null < false < true < numbers < strings < arrays < objects.
+func compare(a, b any) int {
+	if rankA, rankB := rank(a), rank(b); rankA != rankB {
+		return cmp.Compare(rankA, rankB)
+	}
+	switch a := a.(type) {
+	case parsing.Number:
+		return cmp.Compare(a.Float64(), b.(parsing.Number).Float64())
+	case string:
+		return cmp.Compare(a, b.(string))
+	case []any:
+		return slices.CompareFunc(a, b.([]any), compare)
+	case *parsing.Object:
+		b := b.(*parsing.Object)
+		keysA, keysB := sortedKeys(a), sortedKeys(b)
+		if result := slices.Compare(keysA, keysB); result != 0 {
+			return result
+		}
+		for _, key := range keysA {
+			valueA, _ := a.Get(key)
+			valueB, _ := b.Get(key)
+			if result := compare(valueA, valueB); result != 0 {
+				return result
+			}
+		}
+	}
+	return 0
+}
+func rank(value any) int {
+	switch value := value.(type) {
+	case nil:
+		return 0
+	case bool:
+		if value {
+			return 2
+		}
+		return 1
+	case parsing.Number:
+		return 3
+	case string:
+		return 4
+	case []any:
+		return 5
+	default:
+		return 6
+	}
+}
+func sortedKeys(object *parsing.Object) []string {
+	keys := object.Keys()
+	slices.Sort(keys)
+	return slices.Compact(keys)
+}
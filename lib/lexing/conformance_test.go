@@ -0,0 +1,123 @@
+package lexing
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"text/tabwriter"
+
+	"github.com/mdwhatcott/coding-challenges.fyi-json/lib/util/git"
+	"github.com/mdwhatcott/testing/should"
+)
+
+// TestConformance runs the JSONTestSuite-style corpus in testdata/conformance,
+// where files prefixed with y_ must be accepted, n_ must be rejected, and i_
+// are implementation-defined (see implementationDefined for this lexer's decisions).
+func TestConformance(t *testing.T) {
+	corpus := filepath.Join(git.RootDirectory(), "lib", "lexing", "testdata", "conformance")
+	listing, err := os.ReadDir(corpus)
+	if err != nil {
+		t.Fatal(err)
+	}
+	matrix := make(map[string]map[bool]int)
+	for _, entry := range listing {
+		name := entry.Name()
+		category := name[:2]
+		expected, known := expectedConformance(name)
+		t.Run(name, func(t *testing.T) {
+			if !known {
+				t.Fatal("no documented decision for implementation-defined case")
+			}
+			content, err := os.ReadFile(filepath.Join(corpus, name))
+			if err != nil {
+				t.Fatal(err)
+			}
+			actual := isValid(bytes.NewReader(content))
+			if matrix[category] == nil {
+				matrix[category] = make(map[bool]int)
+			}
+			matrix[category][actual]++
+			should.So(t, actual, should.Equal, expected)
+		})
+	}
+	for name := range implementationDefined {
+		if _, err := os.Stat(filepath.Join(corpus, name)); err != nil {
+			t.Errorf("decision recorded for missing case: %s", name)
+		}
+	}
+	t.Log("conformance matrix:\n" + formatMatrix(matrix))
+}
+func expectedConformance(name string) (accept, known bool) {
+	switch {
+	case strings.HasPrefix(name, "y_"):
+		return true, true
+	case strings.HasPrefix(name, "n_"):
+		return false, true
+	default:
+		accept, known = implementationDefined[name]
+		return accept, known
+	}
+}
+func formatMatrix(matrix map[string]map[bool]int) string {
+	var buffer bytes.Buffer
+	writer := tabwriter.NewWriter(&buffer, 0, 0, 2, ' ', tabwriter.AlignRight)
+	_, _ = fmt.Fprintln(writer, "\taccepted\trejected\t")
+	for _, category := range []string{"y_", "n_", "i_"} {
+		_, _ = fmt.Fprintf(writer, "%s\t%d\t%d\t\n", category, matrix[category][true], matrix[category][false])
+	}
+	_ = writer.Flush()
+	return buffer.String()
+}
+
+// implementationDefined records whether the lexer accepts each i_ case. The lexer
+// enforces the RFC 8259 grammar and nothing more, so any content the grammar
+// allows is accepted (`ccjson -profile i-json` rejects most of these).
+var implementationDefined = map[string]bool{
+	// Numbers are checked against the grammar but never converted,
+	// so neither magnitude nor precision is limited.
+	"i_number_double_huge_neg_exp.json":   true,
+	"i_number_huge_exp.json":              true,
+	"i_number_neg_int_huge_exp.json":      true,
+	"i_number_pos_double_huge_exp.json":   true,
+	"i_number_real_neg_overflow.json":     true,
+	"i_number_real_pos_overflow.json":     true,
+	"i_number_real_underflow.json":        true,
+	"i_number_too_big_neg_int.json":       true,
+	"i_number_too_big_pos_int.json":       true,
+	"i_number_very_big_negative_int.json": true,
+
+	// Any four hex digits form a legal \u escape, paired or not.
+	// (lexing.Unquote decodes unpaired surrogates as U+FFFD.)
+	"i_object_key_lone_2nd_surrogate.json":                true,
+	"i_string_1st_surrogate_but_2nd_missing.json":         true,
+	"i_string_1st_valid_surrogate_2nd_invalid.json":       true,
+	"i_string_incomplete_surrogate_and_escape_valid.json": true,
+	"i_string_incomplete_surrogate_pair.json":             true,
+	"i_string_incomplete_surrogates_escape_valid.json":    true,
+	"i_string_invalid_lonely_surrogate.json":              true,
+	"i_string_invalid_surrogate.json":                     true,
+	"i_string_inverted_surrogates_U+1D11E.json":           true,
+	"i_string_lone_second_surrogate.json":                 true,
+
+	// Bytes at or above 0x20 inside strings are passed through unexamined,
+	// so malformed UTF-8 is accepted (`-profile rfc8259` rejects it).
+	"i_string_UTF-8_invalid_sequence.json":      true,
+	"i_string_UTF8_surrogate_U+D800.json":       true,
+	"i_string_invalid_utf-8.json":               true,
+	"i_string_iso_latin_1.json":                 true,
+	"i_string_lone_utf8_continuation_byte.json": true,
+	"i_string_not_in_unicode_range.json":        true,
+	"i_string_overlong_sequence_2_bytes.json":   true,
+	"i_string_truncated-utf-8.json":             true,
+
+	// Input must be UTF-8 without a byte order mark.
+	"i_string_UTF-16LE_with_BOM.json":         false,
+	"i_string_utf16BE_no_BOM.json":            false,
+	"i_structure_UTF-8_BOM_empty_object.json": false,
+
+	// There is no nesting limit.
+	"i_structure_500_nested_arrays.json": true,
+}
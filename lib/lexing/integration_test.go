@@ -20,6 +20,9 @@ func TestIntegration(t *testing.T) {
 		t.Fatal(err)
 	}
 	for _, entry := range listing {
+		if entry.IsDir() {
+			continue
+		}
 		t.Run(entry.Name(), func(t *testing.T) {
 			file, err := os.Open(filepath.Join(testdata, entry.Name()))
 			if err != nil {
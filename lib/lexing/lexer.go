@@ -2,6 +2,7 @@ package lexing
 
 import (
 	"io"
+	"runtime"
 	"slices"
 )
 
@@ -35,10 +36,17 @@ type lexer struct {
 	start  int
 	stop   int
 	output chan Token
+	done   <-chan struct{}
 }
 
 func Lex(source io.Reader) chan Token {
-	lexer := &lexer{source: source, chunk: make([]byte, 1024), output: make(chan Token)}
+	return LexUntil(source, nil)
+}
+
+// LexUntil is like Lex, but the lexer stops reading from source
+// (and closes the returned channel) as soon as done is closed.
+func LexUntil(source io.Reader, done <-chan struct{}) chan Token {
+	lexer := &lexer{source: source, chunk: make([]byte, 1024), output: make(chan Token), done: done}
 	go lexer.lex()
 	return lexer.output
 }
@@ -61,18 +69,21 @@ func (this *lexer) lex() {
 	}
 }
 
-func (this *lexer) readChunk() {
+func (this *lexer) readChunk() bool {
 	n, _ := this.source.Read(this.chunk)
 	this.input = append(this.input, this.chunk[:n]...)
 	clear(this.chunk)
+	return n > 0
 }
 
 func (this *lexer) peek() rune {
 	return this.at(0)
 }
 func (this *lexer) at(offset int) rune {
-	if this.stop >= len(this.input) {
-		return 0
+	for this.stop+offset >= len(this.input) {
+		if !this.readChunk() {
+			return 0
+		}
 	}
 	return rune(this.input[this.stop+offset])
 }
@@ -124,7 +135,11 @@ func (this *lexer) emit(tokenType TokenType) {
 	if tokenType == TokenIllegal {
 		this.stop = len(this.input)
 	}
-	this.output <- Token{Type: tokenType, Value: this.input[this.start:this.stop]}
+	select {
+	case this.output <- Token{Type: tokenType, Value: this.input[this.start:this.stop]}:
+	case <-this.done:
+		runtime.Goexit()
+	}
 	this.input = this.input[this.stop:]
 	this.start, this.stop = 0, 0
 }
@@ -163,9 +178,9 @@ func (this *lexer) acceptNumber() bool {
 	if !couldBeNumber(this.peek()) {
 		return this.ignore()
 	}
-	_ = this.accept(sign...)
-	if !this.accept(zero) {
-		_ = this.acceptRun(digits...)
+	_ = this.accept(negative)
+	if !this.accept(zero) && this.acceptRun(digits...) == 0 {
+		return this.ignore()
 	}
 	if this.accept(decimalPoint) {
 		if this.acceptRun(digits...) == 0 {
@@ -287,9 +302,8 @@ func (this *lexer) acceptObject() bool {
 	return this.ignore()
 }
 
-func couldBeNumber(r rune) bool { return isSign(r) || isDigit(r) }
+func couldBeNumber(r rune) bool { return r == negative || isDigit(r) }
 func isDigit(r rune) bool       { return zero <= r && r <= nine }
-func isSign(r rune) bool        { return r == positive || r == negative }
 
 var (
 	_null       = []rune("null")
@@ -297,7 +311,7 @@ var (
 	_false      = []rune("false")
 	whitespaces = []rune{space, '\n', '\r', '\t'}
 	digits      = []rune("0123456789")
-	hexDigits   = append(digits, []rune("abcdefg"+"ABCDEFG")...)
+	hexDigits   = append(digits, []rune("abcdef"+"ABCDEF")...)
 	sign        = []rune{positive, negative}
 	exponent    = []rune{_exponent, _Exponent}
 )
@@ -35,6 +35,8 @@ func TestLex(t *testing.T) {
 		testLex(t, `-1`, token(TokenNumber, "-1"))
 		testLex(t, `-0`, token(TokenNumber, "-0"))
 		testLex(t, `-0.1`, token(TokenNumber, "-0.1"))
+		testLex(t, `-`, token(TokenIllegal, "-"))
+		testLex(t, `+1`, token(TokenIllegal, "+1"))
 		testLex(t, `3.7e-`, token(TokenIllegal, "3.7e-"))
 		testLex(t, `3.7e-5`, token(TokenNumber, "3.7e-5"))
 		testLex(t, `3.7e+5`, token(TokenNumber, "3.7e+5"))
@@ -60,8 +62,13 @@ func TestLex(t *testing.T) {
 		testLex(t, `"\ucdef"`, token(TokenString, `"\ucdef"`))
 		testLex(t, `"\uCDEF"`, token(TokenString, `"\uCDEF"`))
 		testLex(t, `"\u123x"`, token(TokenIllegal, `"\u123x"`))
+		testLex(t, `"\u123g"`, token(TokenIllegal, `"\u123g"`))
+		testLex(t, `"\uG123"`, token(TokenIllegal, `"\uG123"`))
 		testLex(t, `"`+"\t"+`"`, token(TokenIllegal, `"`+"\t"+`"`))
 		testLex(t, `"\x15"`, token(TokenIllegal, `"\x15"`))
+		testLex(t, `"\`, token(TokenIllegal, `"\`))
+		long := `"` + strings.Repeat("a", 1022) + `\n"`
+		testLex(t, long, token(TokenString, long))
 	})
 	t.Run("arrays", func(t *testing.T) {
 		testLex(t, `[`, token(TokenArrayStart, `[`), token(TokenIllegal, ""))
@@ -211,6 +218,15 @@ func TestLex(t *testing.T) {
 		)
 	})
 }
+func TestLexUntil(t *testing.T) {
+	done := make(chan struct{})
+	tokens := LexUntil(strings.NewReader(`[1,2,3]`), done)
+	first := <-tokens
+	close(done)
+	for range tokens {
+	}
+	should.So(t, first, should.Equal, token(TokenArrayStart, "["))
+}
 func lex(s string) (result []Token) {
 	defer func() { recover() }()
 	for token := range Lex(strings.NewReader(s)) {
@@ -0,0 +1,101 @@
+package lexing
+
+import (
+	"strconv"
+	"strings"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+// Unquote decodes the value of a TokenString (surrounding quotes included).
+// Unpaired surrogates are decoded as utf8.RuneError.
+func Unquote(value []byte) string {
+	if len(value) < 2 {
+		return ""
+	}
+	value = value[1 : len(value)-1]
+	var builder strings.Builder
+	builder.Grow(len(value))
+	for x := 0; x < len(value); x++ {
+		if value[x] != reverseSolidus || x+1 >= len(value) {
+			builder.WriteByte(value[x])
+			continue
+		}
+		x++
+		switch value[x] {
+		case backspace:
+			builder.WriteByte('\b')
+		case formFeed:
+			builder.WriteByte('\f')
+		case lineFeed:
+			builder.WriteByte('\n')
+		case carriageReturn:
+			builder.WriteByte('\r')
+		case tab:
+			builder.WriteByte('\t')
+		case unicode:
+			r, ok := unhex(value[x+1:])
+			if !ok {
+				builder.WriteRune(utf8.RuneError)
+				continue
+			}
+			x += 4
+			if utf16.IsSurrogate(r) {
+				low, ok := escapedRune(value[x+1:])
+				if r = utf16.DecodeRune(r, low); ok && r != utf8.RuneError {
+					x += 6
+				}
+			}
+			builder.WriteRune(r)
+		default:
+			builder.WriteByte(value[x])
+		}
+	}
+	return builder.String()
+}
+func escapedRune(value []byte) (rune, bool) {
+	if len(value) < 2 || value[0] != reverseSolidus || value[1] != unicode {
+		return 0, false
+	}
+	return unhex(value[2:])
+}
+func unhex(value []byte) (rune, bool) {
+	if len(value) < 4 {
+		return 0, false
+	}
+	n, err := strconv.ParseUint(string(value[:4]), 16, 32)
+	return rune(n), err == nil
+}
+
+// Quote encodes value as the text of a TokenString, escaping only what JSON
+// requires: quotation marks, reverse solidi and control characters (using the
+// two-character escapes where they exist).
+func Quote(value string) []byte {
+	result := make([]byte, 0, len(value)+2)
+	result = append(result, quote)
+	for x := 0; x < len(value); x++ {
+		switch c := value[x]; c {
+		case quote, reverseSolidus:
+			result = append(result, reverseSolidus, c)
+		case '\b':
+			result = append(result, reverseSolidus, backspace)
+		case '\f':
+			result = append(result, reverseSolidus, formFeed)
+		case '\n':
+			result = append(result, reverseSolidus, lineFeed)
+		case '\r':
+			result = append(result, reverseSolidus, carriageReturn)
+		case '\t':
+			result = append(result, reverseSolidus, tab)
+		default:
+			if c < 0x20 {
+				result = append(result, reverseSolidus, unicode, '0', '0', lowerHex[c>>4], lowerHex[c&0xF])
+			} else {
+				result = append(result, c)
+			}
+		}
+	}
+	return append(result, quote)
+}
+
+const lowerHex = "0123456789abcdef"
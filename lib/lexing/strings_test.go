@@ -0,0 +1,39 @@
+package lexing
+
+import (
+	"testing"
+
+	"github.com/mdwhatcott/testing/should"
+)
+
+func TestUnquote(t *testing.T) {
+	testUnquote(t, `""`, "")
+	testUnquote(t, `"abc"`, "abc")
+	testUnquote(t, `"\"\\\/"`, `"\/`)
+	testUnquote(t, `"\b\f\n\r\t"`, "\b\f\n\r\t")
+	testUnquote(t, `"\u0041\u00e9"`, "Aé")
+	testUnquote(t, `"\uD834\uDD1E"`, "𝄞")
+	testUnquote(t, `"\uD834"`, "�")
+	testUnquote(t, `"\uD834x"`, "�x")
+	testUnquote(t, `"\uDD1E\uD834"`, "��")
+	testUnquote(t, `"日本"`, "日本")
+}
+func testUnquote(t *testing.T, input, expected string) {
+	t.Run(input, func(t *testing.T) {
+		should.So(t, Unquote([]byte(input)), should.Equal, expected)
+	})
+}
+func TestQuote(t *testing.T) {
+	testQuote(t, "", `""`)
+	testQuote(t, "abc", `"abc"`)
+	testQuote(t, `"\/`, `"\"\\/"`)
+	testQuote(t, "\b\f\n\r\t", `"\b\f\n\r\t"`)
+	testQuote(t, "\x00\x1f\x7f", `"\u0000\u001f`+"\x7f"+`"`)
+	testQuote(t, "é€𝄞", `"é€𝄞"`)
+}
+func testQuote(t *testing.T, input, expected string) {
+	t.Run(input, func(t *testing.T) {
+		should.So(t, string(Quote(input)), should.Equal, expected)
+		should.So(t, Unquote([]byte(expected)), should.Equal, input)
+	})
+}
@@ -0,0 +1,19 @@
+package parsing
+
+import (
+	"io"
+
+	"github.com/mdwhatcott/coding-challenges.fyi-json/lib/pointer"
+	"github.com/mdwhatcott/coding-challenges.fyi-json/lib/streaming"
+)
+
+// Elements is like streaming.Elements, but yields each element as a decoded value.
+func Elements(source io.Reader, target pointer.Pointer, yield func(value any) error) error {
+	return streaming.Elements(source, target, func(raw []byte) error {
+		value, err := ParseBytes(raw)
+		if err != nil {
+			return err
+		}
+		return yield(value)
+	})
+}
@@ -0,0 +1,22 @@
+package parsing
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/mdwhatcott/coding-challenges.fyi-json/lib/pointer"
+	"github.com/mdwhatcott/testing/should"
+)
+
+func TestElements(t *testing.T) {
+	var values []any
+	err := Elements(strings.NewReader(`{"items": [1, {"a": "b"}]}`), pointer.Pointer{"items"}, func(value any) error {
+		values = append(values, value)
+		return nil
+	})
+	should.So(t, err, should.BeNil)
+	should.So(t, values, should.Equal, []any{
+		Number("1"),
+		&Object{Members: []Member{{Key: "a", Value: "b"}}},
+	})
+}
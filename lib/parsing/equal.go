@@ -0,0 +1,72 @@
+package parsing
+
+import (
+	"cmp"
+	"math"
+	"math/big"
+	"strconv"
+)
+
+// Float64 converts the number, saturating to ±Inf when out of range.
+func (this Number) Float64() float64 {
+	value, _ := strconv.ParseFloat(string(this), 64)
+	return value
+}
+
+// CompareNumbers compares numbers as doubles, or, when either is beyond the
+// range of doubles (where it would be ±Inf, or 0), with far greater precision.
+func CompareNumbers(a, b Number) int {
+	x, y := a.Float64(), b.Float64()
+	if !math.IsInf(x, 0) && !math.IsInf(y, 0) && x != 0 && y != 0 {
+		return cmp.Compare(x, y)
+	}
+	bigX, _, errX := big.ParseFloat(string(a), 10, 512, big.ToNearestEven)
+	bigY, _, errY := big.ParseFloat(string(b), 10, 512, big.ToNearestEven)
+	if errX != nil || errY != nil {
+		return cmp.Compare(x, y)
+	}
+	return bigX.Cmp(bigY)
+}
+
+// Equal reports whether a and b are semantically equal: numbers are compared
+// by value and objects without regard to member order.
+func Equal(a, b any) bool {
+	switch a := a.(type) {
+	case nil:
+		return b == nil
+	case bool:
+		b, ok := b.(bool)
+		return ok && a == b
+	case Number:
+		b, ok := b.(Number)
+		return ok && (a == b || CompareNumbers(a, b) == 0)
+	case string:
+		b, ok := b.(string)
+		return ok && a == b
+	case []any:
+		b, ok := b.([]any)
+		if !ok || len(a) != len(b) {
+			return false
+		}
+		for x := range a {
+			if !Equal(a[x], b[x]) {
+				return false
+			}
+		}
+		return true
+	case *Object:
+		b, ok := b.(*Object)
+		if !ok || a.Len() != b.Len() {
+			return false
+		}
+		for _, member := range a.Members {
+			value, ok := b.Get(member.Key)
+			if !ok || !Equal(member.Value, value) {
+				return false
+			}
+		}
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,63 @@
+package parsing
+
+import (
+	"testing"
+
+	"github.com/mdwhatcott/testing/should"
+)
+
+func TestEqual(t *testing.T) {
+	testEqual(t, `null`, `null`, true)
+	testEqual(t, `null`, `false`, false)
+	testEqual(t, `true`, `true`, true)
+	testEqual(t, `true`, `false`, false)
+	testEqual(t, `1`, `1.0`, true)
+	testEqual(t, `1`, `1e0`, true)
+	testEqual(t, `1`, `2`, false)
+	testEqual(t, `[1e400]`, `[2e400]`, false)
+	testEqual(t, `1e400`, `10e399`, true)
+	testEqual(t, `-1e400`, `1e400`, false)
+	testEqual(t, `1e400`, `1.7976931348623157e308`, false)
+	testEqual(t, `1e-400`, `0`, false)
+	testEqual(t, `0`, `-0.0`, true)
+	testEqual(t, `1`, `"1"`, false)
+	testEqual(t, `"a"`, `"a"`, true)
+	testEqual(t, `[1, 2]`, `[1, 2]`, true)
+	testEqual(t, `[1, 2]`, `[2, 1]`, false)
+	testEqual(t, `[1]`, `[1, 1]`, false)
+	testEqual(t, `{"a": 1, "b": [2]}`, `{"b": [2], "a": 1}`, true)
+	testEqual(t, `{"a": 1}`, `{"a": 1, "b": 2}`, false)
+	testEqual(t, `{"a": 1}`, `{"b": 1}`, false)
+	testEqual(t, `{}`, `[]`, false)
+}
+func TestCompareNumbers(t *testing.T) {
+	for _, test := range []struct {
+		a, b     Number
+		expected int
+	}{
+		{"1", "2", -1},
+		{"2", "1e0", 1},
+		{"1e400", "2e400", -1},
+		{"1e400", "10e399", 0},
+		{"-1e400", "1.7976931348623157e308", -1},
+		{"1e-400", "0", 1},
+		{"-1e-400", "1e-401", -1},
+		{"0", "-0", 0},
+	} {
+		should.So(t, CompareNumbers(test.a, test.b), should.Equal, test.expected)
+		should.So(t, CompareNumbers(test.b, test.a), should.Equal, -test.expected)
+	}
+}
+func testEqual(t *testing.T, a, b string, expected bool) {
+	t.Run(a+" "+b, func(t *testing.T) {
+		should.So(t, Equal(parse(t, a), parse(t, b)), should.Equal, expected)
+		should.So(t, Equal(parse(t, b), parse(t, a)), should.Equal, expected)
+	})
+}
+func parse(t *testing.T, input string) any {
+	value, err := ParseBytes([]byte(input))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return value
+}
@@ -0,0 +1,82 @@
+package parsing
+
+import (
+	"bytes"
+	"errors"
+	"io"
+
+	"github.com/mdwhatcott/coding-challenges.fyi-json/lib/streaming"
+)
+
+var ErrEmptyDocument = errors.New("empty document")
+
+// Parse reads a complete document from source into the types described in value.go.
+func Parse(source io.Reader) (any, error) {
+	builder := &builder{}
+	err := streaming.Walk(source, builder)
+	if err != nil {
+		return nil, err
+	}
+	if !builder.complete {
+		return nil, ErrEmptyDocument
+	}
+	return builder.result, nil
+}
+func ParseBytes(source []byte) (any, error) {
+	return Parse(bytes.NewReader(source))
+}
+
+type builder struct {
+	stack    []*container
+	result   any
+	complete bool
+}
+
+type container struct {
+	array  []any
+	object *Object
+	key    string
+}
+
+func (this *builder) StartObject() error {
+	this.stack = append(this.stack, &container{object: &Object{}})
+	return nil
+}
+func (this *builder) Key(key string) error {
+	this.stack[len(this.stack)-1].key = key
+	return nil
+}
+func (this *builder) EndObject() error {
+	return this.add(this.pop().object)
+}
+func (this *builder) StartArray() error {
+	this.stack = append(this.stack, &container{array: []any{}})
+	return nil
+}
+func (this *builder) EndArray() error {
+	return this.add(this.pop().array)
+}
+func (this *builder) String(value string) error { return this.add(value) }
+func (this *builder) Number(value string) error { return this.add(Number(value)) }
+func (this *builder) Bool(value bool) error     { return this.add(value) }
+func (this *builder) Null() error               { return this.add(nil) }
+
+func (this *builder) pop() *container {
+	top := this.stack[len(this.stack)-1]
+	this.stack = this.stack[:len(this.stack)-1]
+	return top
+}
+func (this *builder) add(value any) error {
+	if len(this.stack) == 0 {
+		this.result = value
+		this.complete = true
+		return nil
+	}
+	top := this.stack[len(this.stack)-1]
+	if top.object != nil {
+		top.object.Members = append(top.object.Members, Member{Key: top.key, Value: value})
+	} else {
+		top.array = append(top.array, value)
+	}
+	return nil
+}
@@ -0,0 +1,40 @@
+package parsing
+
+import (
+	"testing"
+
+	"github.com/mdwhatcott/testing/should"
+)
+
+func TestParse(t *testing.T) {
+	testParse(t, `null`, nil)
+	testParse(t, `true`, true)
+	testParse(t, `false`, false)
+	testParse(t, `-1.50e3`, Number("-1.50e3"))
+	testParse(t, `"aé"`, "aé")
+	testParse(t, `[]`, []any{})
+	testParse(t, `{}`, &Object{})
+	testParse(t, `[1, [true], {"a": null}]`, []any{
+		Number("1"),
+		[]any{true},
+		&Object{Members: []Member{{Key: "a", Value: nil}}},
+	})
+	testParse(t, `{"b": 1, "a": {"c": []}, "b": 2}`, &Object{Members: []Member{
+		{Key: "b", Value: Number("1")},
+		{Key: "a", Value: &Object{Members: []Member{{Key: "c", Value: []any{}}}}},
+		{Key: "b", Value: Number("2")},
+	}})
+}
+func testParse(t *testing.T, input string, expected any) {
+	t.Run(input, func(t *testing.T) {
+		actual, err := ParseBytes([]byte(input))
+		should.So(t, err, should.BeNil)
+		should.So(t, actual, should.Equal, expected)
+	})
+}
+func TestParseErrors(t *testing.T) {
+	_, err := ParseBytes(nil)
+	should.So(t, err, should.WrapError, ErrEmptyDocument)
+	_, err = ParseBytes([]byte(`[1,]`))
+	should.So(t, err, should.NOT.BeNil)
+}
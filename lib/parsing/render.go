@@ -0,0 +1,49 @@
+package parsing
+
+import (
+	"fmt"
+
+	"github.com/mdwhatcott/coding-challenges.fyi-json/lib/lexing"
+	"github.com/mdwhatcott/coding-challenges.fyi-json/lib/printing"
+)
+
+// Render sends value to printer as the stream of (whitespace-free)
+// tokens a lexer would produce for it.
+func Render(value any, printer printing.Printer) {
+	switch value := value.(type) {
+	case nil:
+		printer.Print(lexing.Token{Type: lexing.TokenNull, Value: []byte("null")})
+	case bool:
+		if value {
+			printer.Print(lexing.Token{Type: lexing.TokenTrue, Value: []byte("true")})
+		} else {
+			printer.Print(lexing.Token{Type: lexing.TokenFalse, Value: []byte("false")})
+		}
+	case Number:
+		printer.Print(lexing.Token{Type: lexing.TokenNumber, Value: []byte(value)})
+	case string:
+		printer.Print(lexing.Token{Type: lexing.TokenString, Value: lexing.Quote(value)})
+	case []any:
+		printer.Print(lexing.Token{Type: lexing.TokenArrayStart, Value: []byte("[")})
+		for x, element := range value {
+			if x > 0 {
+				printer.Print(lexing.Token{Type: lexing.TokenComma, Value: []byte(",")})
+			}
+			Render(element, printer)
+		}
+		printer.Print(lexing.Token{Type: lexing.TokenArrayStop, Value: []byte("]")})
+	case *Object:
+		printer.Print(lexing.Token{Type: lexing.TokenObjectStart, Value: []byte("{")})
+		for x, member := range value.Members {
+			if x > 0 {
+				printer.Print(lexing.Token{Type: lexing.TokenComma, Value: []byte(",")})
+			}
+			printer.Print(lexing.Token{Type: lexing.TokenString, Value: lexing.Quote(member.Key)})
+			printer.Print(lexing.Token{Type: lexing.TokenColon, Value: []byte(":")})
+			Render(member.Value, printer)
+		}
+		printer.Print(lexing.Token{Type: lexing.TokenObjectStop, Value: []byte("}")})
+	default:
+		panic(fmt.Sprintf("unsupported type: %T", value))
+	}
+}
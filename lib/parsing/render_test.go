@@ -0,0 +1,18 @@
+package parsing
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/mdwhatcott/coding-challenges.fyi-json/lib/printing"
+	"github.com/mdwhatcott/testing/should"
+)
+
+func TestRender(t *testing.T) {
+	input := `{"a": [null, true, false, -1.5e3, "x\ny"], "b": {}, "c": [], "d": {"e": "é"}}`
+	value, err := ParseBytes([]byte(input))
+	should.So(t, err, should.BeNil)
+	out := &bytes.Buffer{}
+	Render(value, printing.NewVerbatimPrinter(out))
+	should.So(t, out.String(), should.Equal, `{"a":[null,true,false,-1.5e3,"x\ny"],"b":{},"c":[],"d":{"e":"é"}}`)
+}
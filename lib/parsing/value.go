@@ -0,0 +1,94 @@
+package parsing
+
+// Documents are represented with the following Go types:
+//
+//	null    -> nil
+//	boolean -> bool
+//	number  -> Number
+//	string  -> string
+//	array   -> []any
+//	object  -> *Object
+
+// Number holds the literal text of a JSON number, preserving its precision.
+type Number string
+
+// Object holds the members of a JSON object in document order.
+type Object struct {
+	Members []Member
+}
+
+type Member struct {
+	Key   string
+	Value any
+}
+
+func (this *Object) Len() int {
+	return len(this.Members)
+}
+func (this *Object) Keys() (keys []string) {
+	for _, member := range this.Members {
+		keys = append(keys, member.Key)
+	}
+	return keys
+}
+
+// Get returns the value of the last member with the given key.
+func (this *Object) Get(key string) (any, bool) {
+	index := this.index(key)
+	if index < 0 {
+		return nil, false
+	}
+	return this.Members[index].Value, true
+}
+
+// Set replaces the value of the member with the given key, or appends a new member.
+func (this *Object) Set(key string, value any) {
+	index := this.index(key)
+	if index < 0 {
+		this.Members = append(this.Members, Member{Key: key, Value: value})
+	} else {
+		this.Members[index].Value = value
+	}
+}
+
+// Delete removes all members with the given key, reporting whether there were any.
+func (this *Object) Delete(key string) (deleted bool) {
+	members := this.Members[:0]
+	for _, member := range this.Members {
+		if member.Key == key {
+			deleted = true
+		} else {
+			members = append(members, member)
+		}
+	}
+	this.Members = members
+	return deleted
+}
+func (this *Object) index(key string) int {
+	for x := len(this.Members) - 1; x >= 0; x-- {
+		if this.Members[x].Key == key {
+			return x
+		}
+	}
+	return -1
+}
+
+// Clone returns a deep copy of value, so the copy may be modified independently.
+func Clone(value any) any {
+	switch value := value.(type) {
+	case []any:
+		result := make([]any, len(value))
+		for x, element := range value {
+			result[x] = Clone(element)
+		}
+		return result
+	case *Object:
+		result := &Object{Members: make([]Member, len(value.Members))}
+		for x, member := range value.Members {
+			result.Members[x] = Member{Key: member.Key, Value: Clone(member.Value)}
+		}
+		return result
+	default:
+		return value
+	}
+}
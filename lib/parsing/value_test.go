@@ -0,0 +1,32 @@
+package parsing
+
+import (
+	"testing"
+
+	"github.com/mdwhatcott/testing/should"
+)
+
+func TestObject(t *testing.T) {
+	object := &Object{}
+	object.Set("a", Number("1"))
+	object.Set("b", Number("2"))
+	object.Set("a", Number("3"))
+	should.So(t, object.Keys(), should.Equal, []string{"a", "b"})
+	value, ok := object.Get("a")
+	should.So(t, ok, should.BeTrue)
+	should.So(t, value, should.Equal, Number("3"))
+	should.So(t, object.Delete("a"), should.BeTrue)
+	should.So(t, object.Delete("a"), should.BeFalse)
+	_, ok = object.Get("a")
+	should.So(t, ok, should.BeFalse)
+	should.So(t, object.Len(), should.Equal, 1)
+}
+
+func TestClone(t *testing.T) {
+	original := parse(t, `{"a": [1, {"b": null}], "c": "d"}`)
+	clone := Clone(original)
+	should.So(t, clone, should.Equal, original)
+	clone.(*Object).Members[0].Value.([]any)[1].(*Object).Set("b", true)
+	clone.(*Object).Set("e", false)
+	should.So(t, Equal(original, parse(t, `{"a": [1, {"b": null}], "c": "d"}`)), should.BeTrue)
+}
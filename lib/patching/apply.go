@@ -0,0 +1,215 @@
+package patching
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+
+	"github.com/mdwhatcott/coding-challenges.fyi-json/lib/parsing"
+	"github.com/mdwhatcott/coding-challenges.fyi-json/lib/pointer"
+)
+
+// Error identifies the operation that failed and why.
+type Error struct {
+	Index     int
+	Operation Operation
+	Reason    string
+}
+
+func (this *Error) Error() string {
+	return fmt.Sprintf("operation %d (%s) failed: %s", this.Index, this.Operation, this.Reason)
+}
+
+// Apply applies operations to a copy of document, returning the result. The
+// operations are atomic: if any of them fails, an *Error is returned and
+// document is left as it was.
+func Apply(document any, operations []Operation) (any, error) {
+	result := parsing.Clone(document)
+	for x, operation := range operations {
+		var err error
+		result, err = apply(result, operation)
+		if err != nil {
+			return document, &Error{Index: x, Operation: operation, Reason: err.Error()}
+		}
+	}
+	return result, nil
+}
+func apply(document any, operation Operation) (any, error) {
+	switch operation.Op {
+	case "add":
+		return add(document, operation.Path, operation.Value)
+	case "remove":
+		document, _, err := remove(document, operation.Path)
+		return document, err
+	case "replace":
+		return replace(document, operation.Path, operation.Value)
+	case "move":
+		if operation.Path.HasPrefix(operation.From) && !operation.Path.Equal(operation.From) {
+			return document, fmt.Errorf("cannot move a value into itself")
+		}
+		document, value, err := remove(document, operation.From)
+		if err != nil {
+			return document, err
+		}
+		return add(document, operation.Path, value)
+	case "copy":
+		value, err := get(document, operation.From)
+		if err != nil {
+			return document, err
+		}
+		return add(document, operation.Path, parsing.Clone(value))
+	case "test":
+		value, err := get(document, operation.Path)
+		if err != nil {
+			return document, err
+		}
+		if !parsing.Equal(value, operation.Value) {
+			return document, fmt.Errorf("value at %q is not equal to the expected value", operation.Path)
+		}
+		return document, nil
+	default:
+		return document, fmt.Errorf("unknown op %q", operation.Op)
+	}
+}
+
+func get(document any, path pointer.Pointer) (any, error) {
+	for x, token := range path {
+		var err error
+		document, err = child(document, path[:x], token)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return document, nil
+}
+func add(document any, path pointer.Pointer, value any) (any, error) {
+	if len(path) == 0 {
+		return value, nil
+	}
+	return modify(document, path, func(parent any, token string) (any, error) {
+		switch parent := parent.(type) {
+		case *parsing.Object:
+			parent.Set(token, value)
+			return parent, nil
+		case []any:
+			x := len(parent)
+			if token != "-" {
+				var err error
+				x, err = arrayIndex(parent, path, len(parent)+1)
+				if err != nil {
+					return parent, err
+				}
+			}
+			parent = append(parent, nil)
+			copy(parent[x+1:], parent[x:])
+			parent[x] = value
+			return parent, nil
+		default:
+			return parent, fmt.Errorf("value at %q is %s, not a container", path[:len(path)-1], describe(parent))
+		}
+	})
+}
+func replace(document any, path pointer.Pointer, value any) (any, error) {
+	if len(path) == 0 {
+		return value, nil
+	}
+	return modify(document, path, func(parent any, token string) (any, error) {
+		if _, err := child(parent, path[:len(path)-1], token); err != nil {
+			return parent, err
+		}
+		switch parent := parent.(type) {
+		case *parsing.Object:
+			parent.Set(token, value)
+		case []any:
+			x, _ := strconv.Atoi(token)
+			parent[x] = value
+		}
+		return parent, nil
+	})
+}
+func remove(document any, path pointer.Pointer) (result any, removed any, err error) {
+	if len(path) == 0 {
+		return document, nil, fmt.Errorf("cannot remove the whole document")
+	}
+	result, err = modify(document, path, func(parent any, token string) (any, error) {
+		removed, err = child(parent, path[:len(path)-1], token)
+		if err != nil {
+			return parent, err
+		}
+		switch parent := parent.(type) {
+		case *parsing.Object:
+			parent.Delete(token)
+			return parent, nil
+		default:
+			array := parent.([]any)
+			x, _ := strconv.Atoi(token)
+			return append(array[:x], array[x+1:]...), nil
+		}
+	})
+	return result, removed, err
+}
+
+// modify locates the parent of the value at path and replaces it with the result of change.
+func modify(document any, path pointer.Pointer, change func(parent any, token string) (any, error)) (any, error) {
+	parentPath, token := path[:len(path)-1], path[len(path)-1]
+	parent, err := get(document, parentPath)
+	if err != nil {
+		return document, err
+	}
+	updated, err := change(parent, token)
+	if err != nil {
+		return document, err
+	}
+	if len(parentPath) == 0 {
+		return updated, nil
+	}
+	grandparent, _ := get(document, parentPath[:len(parentPath)-1])
+	last := parentPath[len(parentPath)-1]
+	switch grandparent := grandparent.(type) {
+	case *parsing.Object:
+		grandparent.Set(last, updated)
+	case []any:
+		x, _ := strconv.Atoi(last)
+		grandparent[x] = updated
+	}
+	return document, nil
+}
+
+// child returns the member or element named by token of the container found at path.
+func child(container any, path pointer.Pointer, token string) (any, error) {
+	switch container := container.(type) {
+	case *parsing.Object:
+		value, ok := container.Get(token)
+		if !ok {
+			return nil, fmt.Errorf("no member %q in object at %q", token, path)
+		}
+		return value, nil
+	case []any:
+		x, err := arrayIndex(container, append(path[:len(path):len(path)], token), len(container))
+		if err != nil {
+			return nil, err
+		}
+		return container[x], nil
+	default:
+		return nil, fmt.Errorf("value at %q is %s, not a container", path, describe(container))
+	}
+}
+
+// arrayIndex parses the last token of path as an index less than limit.
+// arrayIndexPattern is the grammar of array indices in RFC 6901 (section 4).
+var arrayIndexPattern = regexp.MustCompile(`^(0|[1-9][0-9]*)$`)
+
+func arrayIndex(array []any, path pointer.Pointer, limit int) (int, error) {
+	token := path[len(path)-1]
+	if !arrayIndexPattern.MatchString(token) {
+		return 0, fmt.Errorf("invalid array index %q in %q", token, path)
+	}
+	x, err := strconv.Atoi(token)
+	if err != nil {
+		x = limit // (too large for an int, so certainly out of bounds)
+	}
+	if x >= limit {
+		return 0, fmt.Errorf("array index %s out of bounds in %q (array length is %d)", token, path, len(array))
+	}
+	return x, nil
+}
@@ -0,0 +1,95 @@
+package patching
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/mdwhatcott/coding-challenges.fyi-json/lib/parsing"
+	"github.com/mdwhatcott/coding-challenges.fyi-json/lib/printing"
+	"github.com/mdwhatcott/testing/should"
+)
+
+// Examples from RFC 6902, Appendix A.
+func TestApply(t *testing.T) {
+	testApply(t, `{"foo": "bar"}`, `[{"op": "add", "path": "/baz", "value": "qux"}]`, `{"foo":"bar","baz":"qux"}`)
+	testApply(t, `{"foo": ["bar", "baz"]}`, `[{"op": "add", "path": "/foo/1", "value": "qux"}]`, `{"foo":["bar","qux","baz"]}`)
+	testApply(t, `{"baz": "qux", "foo": "bar"}`, `[{"op": "remove", "path": "/baz"}]`, `{"foo":"bar"}`)
+	testApply(t, `{"foo": ["bar", "qux", "baz"]}`, `[{"op": "remove", "path": "/foo/1"}]`, `{"foo":["bar","baz"]}`)
+	testApply(t, `{"baz": "qux", "foo": "bar"}`, `[{"op": "replace", "path": "/baz", "value": "boo"}]`, `{"baz":"boo","foo":"bar"}`)
+	testApply(t, `{"foo": {"bar": "baz", "waldo": "fred"}, "qux": {"corge": "grault"}}`,
+		`[{"op": "move", "from": "/foo/waldo", "path": "/qux/thud"}]`,
+		`{"foo":{"bar":"baz"},"qux":{"corge":"grault","thud":"fred"}}`)
+	testApply(t, `{"foo": ["all", "grass", "cows", "eat"]}`, `[{"op": "move", "from": "/foo/1", "path": "/foo/3"}]`, `{"foo":["all","cows","eat","grass"]}`)
+	testApply(t, `{"baz": "qux", "foo": ["a", 2, "c"]}`,
+		`[{"op": "test", "path": "/baz", "value": "qux"}, {"op": "test", "path": "/foo/1", "value": 2}]`,
+		`{"baz":"qux","foo":["a",2,"c"]}`)
+	testApply(t, `{"foo": "bar"}`, `[{"op": "add", "path": "/child", "value": {"grandchild": {}}}]`, `{"foo":"bar","child":{"grandchild":{}}}`)
+	testApply(t, `{"foo": ["bar"]}`, `[{"op": "add", "path": "/foo/-", "value": ["abc", "def"]}]`, `{"foo":["bar",["abc","def"]]}`)
+	testApply(t, `{"/": 9, "~1": 10}`, `[{"op": "test", "path": "/~01", "value": 10}]`, `{"/":9,"~1":10}`)
+	testApply(t, `{"foo": null}`, `[{"op": "test", "path": "/foo", "value": null}]`, `{"foo":null}`)
+	testApply(t, `{"foo": 1}`, `[{"op": "copy", "from": "/foo", "path": "/bar"}]`, `{"foo":1,"bar":1}`)
+	testApply(t, `{"foo": 1}`, `[{"op": "replace", "path": "", "value": [1]}]`, `[1]`)
+	testApply(t, `{"a": {"b": [1, {"c": 2}]}}`, `[{"op": "replace", "path": "/a/b/1/c", "value": 3}]`, `{"a":{"b":[1,{"c":3}]}}`)
+}
+func testApply(t *testing.T, document, patch, expected string) {
+	t.Run(patch, func(t *testing.T) {
+		operations, err := ParseOperations(parse(t, patch))
+		should.So(t, err, should.BeNil)
+		result, err := Apply(parse(t, document), operations)
+		should.So(t, err, should.BeNil)
+		should.So(t, compact(result), should.Equal, expected)
+	})
+}
+func TestApply_Errors(t *testing.T) {
+	testApplyError(t, `{"baz": "qux"}`, `[{"op": "test", "path": "/baz", "value": "bar"}]`,
+		`operation 0 (test "/baz") failed: value at "/baz" is not equal to the expected value`)
+	testApplyError(t, `{"foo": "bar"}`, `[{"op": "add", "path": "/baz/bat", "value": "qux"}]`,
+		`operation 0 (add "/baz/bat") failed: no member "baz" in object at ""`)
+	testApplyError(t, `{"foo": "bar"}`, `[{"op": "remove", "path": "/foo/0"}]`,
+		`operation 0 (remove "/foo/0") failed: value at "/foo" is a string, not a container`)
+	testApplyError(t, `{"foo": [1]}`, `[{"op": "add", "path": "/foo/2", "value": 3}]`,
+		`operation 0 (add "/foo/2") failed: array index 2 out of bounds in "/foo/2" (array length is 1)`)
+	testApplyError(t, `{"foo": [1]}`, `[{"op": "replace", "path": "/foo/01", "value": 3}]`,
+		`operation 0 (replace "/foo/01") failed: invalid array index "01" in "/foo/01"`)
+	testApplyError(t, `{"foo": [1]}`, `[{"op": "replace", "path": "/foo/-0", "value": 3}]`,
+		`operation 0 (replace "/foo/-0") failed: invalid array index "-0" in "/foo/-0"`)
+	testApplyError(t, `{"foo": [1]}`, `[{"op": "remove", "path": "/foo/+0"}]`,
+		`operation 0 (remove "/foo/+0") failed: invalid array index "+0" in "/foo/+0"`)
+	testApplyError(t, `{"foo": [1]}`, `[{"op": "remove", "path": "/foo/99999999999999999999"}]`,
+		`operation 0 (remove "/foo/99999999999999999999") failed: array index 99999999999999999999 out of bounds in "/foo/99999999999999999999" (array length is 1)`)
+	testApplyError(t, `{"foo": [1]}`, `[{"op": "remove", "path": "/foo/-"}]`,
+		`operation 0 (remove "/foo/-") failed: invalid array index "-" in "/foo/-"`)
+	testApplyError(t, `{"a": {"b": 1}}`, `[{"op": "move", "from": "/a", "path": "/a/c"}]`,
+		`operation 0 (move "/a" -> "/a/c") failed: cannot move a value into itself`)
+	testApplyError(t, `{}`, `[{"op": "copy", "from": "/x", "path": "/y"}]`,
+		`operation 0 (copy "/x" -> "/y") failed: no member "x" in object at ""`)
+	testApplyError(t, `{}`, `[{"op": "remove", "path": ""}]`,
+		`operation 0 (remove "") failed: cannot remove the whole document`)
+}
+func testApplyError(t *testing.T, document, patch, expected string) {
+	t.Run(patch, func(t *testing.T) {
+		operations, err := ParseOperations(parse(t, patch))
+		should.So(t, err, should.BeNil)
+		_, err = Apply(parse(t, document), operations)
+		should.So(t, err, should.NOT.BeNil)
+		should.So(t, err.Error(), should.Equal, expected)
+	})
+}
+func TestApply_Atomic(t *testing.T) {
+	document := parse(t, `{"a": [1, 2], "b": {"c": 3}}`)
+	operations, err := ParseOperations(parse(t, `[
+		{"op": "remove", "path": "/a/0"},
+		{"op": "add", "path": "/b/d", "value": 4},
+		{"op": "test", "path": "/b/c", "value": 4}
+	]`))
+	should.So(t, err, should.BeNil)
+	result, err := Apply(document, operations)
+	should.So(t, err.(*Error).Index, should.Equal, 2)
+	should.So(t, compact(result), should.Equal, `{"a":[1,2],"b":{"c":3}}`)
+	should.So(t, compact(document), should.Equal, `{"a":[1,2],"b":{"c":3}}`)
+}
+func compact(value any) string {
+	var out bytes.Buffer
+	parsing.Render(value, printing.NewCompactPrinter(&out))
+	return out.String()
+}
@@ -0,0 +1,92 @@
+package patching
+
+import (
+	"strconv"
+
+	"github.com/mdwhatcott/coding-challenges.fyi-json/lib/diffing"
+	"github.com/mdwhatcott/coding-challenges.fyi-json/lib/parsing"
+	"github.com/mdwhatcott/coding-challenges.fyi-json/lib/pointer"
+)
+
+// Generate returns a patch that transforms document a into document b. Values
+// that differ are replaced at the deepest point of difference, and array
+// elements are aligned like diffing.Compare so insertions and deletions cost
+// one operation each. Member order is not considered, since a patch can't
+// express it without removing and re-adding members.
+func Generate(a, b any) []Operation {
+	generator := &generator{}
+	generator.generate(pointer.Pointer{}, a, b)
+	return generator.operations
+}
+
+type generator struct {
+	operations []Operation
+}
+
+func (this *generator) generate(path pointer.Pointer, a, b any) {
+	switch a := a.(type) {
+	case []any:
+		if b, ok := b.([]any); ok {
+			this.generateArray(path, a, b)
+			return
+		}
+	case *parsing.Object:
+		if b, ok := b.(*parsing.Object); ok {
+			this.generateObject(path, a, b)
+			return
+		}
+	}
+	if !parsing.Equal(a, b) {
+		this.emit(Operation{Op: "replace", Path: path, Value: b})
+	}
+}
+func (this *generator) generateObject(path pointer.Pointer, a, b *parsing.Object) {
+	seen := make(map[string]bool)
+	for _, key := range a.Keys() {
+		if _, ok := b.Get(key); !ok && !seen[key] {
+			this.emit(Operation{Op: "remove", Path: member(path, key)})
+		}
+		seen[key] = true
+	}
+	clear(seen)
+	for _, key := range b.Keys() {
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		bValue, _ := b.Get(key)
+		if aValue, ok := a.Get(key); ok {
+			this.generate(member(path, key), aValue, bValue)
+		} else {
+			this.emit(Operation{Op: "add", Path: member(path, key), Value: bValue})
+		}
+	}
+}
+
+// generateArray emits operations in application order: after handling
+// a[:x] and b[:y], the working array holds b[:y] followed by a[x:].
+func (this *generator) generateArray(path pointer.Pointer, a, b []any) {
+	x, y := 0, 0
+	for _, match := range append(diffing.Align(a, b), [2]int{len(a), len(b)}) {
+		for ; x < match[0] && y < match[1]; x, y = x+1, y+1 {
+			this.generate(index(path, y), a[x], b[y])
+		}
+		for ; x < match[0]; x++ {
+			this.emit(Operation{Op: "remove", Path: index(path, y)})
+		}
+		for ; y < match[1]; y++ {
+			this.emit(Operation{Op: "add", Path: index(path, y), Value: b[y]})
+		}
+		x, y = match[0]+1, match[1]+1
+	}
+}
+func (this *generator) emit(operation Operation) {
+	this.operations = append(this.operations, operation)
+}
+
+func member(path pointer.Pointer, key string) pointer.Pointer {
+	return append(path[:len(path):len(path)], key)
+}
+func index(path pointer.Pointer, x int) pointer.Pointer {
+	return member(path, strconv.Itoa(x))
+}
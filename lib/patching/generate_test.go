@@ -0,0 +1,31 @@
+package patching
+
+import (
+	"testing"
+
+	"github.com/mdwhatcott/coding-challenges.fyi-json/lib/parsing"
+	"github.com/mdwhatcott/testing/should"
+)
+
+func TestGenerate(t *testing.T) {
+	testGenerate(t, `{"a": 1}`, `{"a": 1}`, `[]`)
+	testGenerate(t, `{"a": 1, "b": 2}`, `{"b": 2, "a": 1}`, `[]`)
+	testGenerate(t, `1`, `"x"`, `[{"op":"replace","path":"","value":"x"}]`)
+	testGenerate(t, `{"a": 1, "b": {"c": [1]}}`, `{"b": {"c": [2]}, "d": null}`,
+		`[{"op":"remove","path":"/a"},{"op":"replace","path":"/b/c/0","value":2},{"op":"add","path":"/d","value":null}]`)
+	testGenerate(t, `[1, 2, 3, 4]`, `[0, 1, 2, 4, 5]`,
+		`[{"op":"add","path":"/0","value":0},{"op":"remove","path":"/3"},{"op":"add","path":"/4","value":5}]`)
+	testGenerate(t, `["a", "b", "c"]`, `["c"]`, `[{"op":"remove","path":"/0"},{"op":"remove","path":"/0"}]`)
+	testGenerate(t, `[{"x": 1}, 2]`, `[{"x": 2}, 3, 2]`,
+		`[{"op":"replace","path":"/0/x","value":2},{"op":"add","path":"/1","value":3}]`)
+	testGenerate(t, `{"a~b/c": 1}`, `{"a~b/c": 2}`, `[{"op":"replace","path":"/a~0b~1c","value":2}]`)
+}
+func testGenerate(t *testing.T, a, b, expected string) {
+	t.Run(a+" "+b, func(t *testing.T) {
+		operations := Generate(parse(t, a), parse(t, b))
+		should.So(t, compact(Value(operations)), should.Equal, expected)
+		result, err := Apply(parse(t, a), operations)
+		should.So(t, err, should.BeNil)
+		should.So(t, parsing.Equal(result, parse(t, b)), should.BeTrue)
+	})
+}
@@ -0,0 +1,85 @@
+package patching
+
+import (
+	"fmt"
+
+	"github.com/mdwhatcott/coding-challenges.fyi-json/lib/parsing"
+	"github.com/mdwhatcott/coding-challenges.fyi-json/lib/pointer"
+)
+
+// Merge applies a JSON Merge Patch (RFC 7396) to a copy of target: members of
+// an object patch replace or (when null) delete the target's members, recursively.
+// Replaced members keep their position in the target; new members are appended.
+func Merge(target, patch any) any {
+	patchObject, ok := patch.(*parsing.Object)
+	if !ok {
+		return parsing.Clone(patch)
+	}
+	result, ok := parsing.Clone(target).(*parsing.Object)
+	if !ok {
+		result = &parsing.Object{}
+	}
+	for _, member := range patchObject.Members {
+		if member.Value == nil {
+			result.Delete(member.Key)
+			continue
+		}
+		value, _ := result.Get(member.Key)
+		result.Set(member.Key, Merge(value, member.Value))
+	}
+	return result
+}
+
+// GenerateMerge returns a merge patch that transforms a into b. Since null
+// means delete, it fails when b has null members that the patch would need to set.
+func GenerateMerge(a, b any) (any, error) {
+	return generateMerge(pointer.Pointer{}, a, b)
+}
+func generateMerge(path pointer.Pointer, a, b any) (any, error) {
+	bObject, ok := b.(*parsing.Object)
+	if !ok {
+		return parsing.Clone(b), nil
+	}
+	aObject, ok := a.(*parsing.Object)
+	if !ok {
+		return parsing.Clone(b), verifyNoNulls(path, bObject)
+	}
+	patch := &parsing.Object{}
+	for _, key := range aObject.Keys() {
+		if _, ok := bObject.Get(key); !ok {
+			patch.Set(key, nil)
+		}
+	}
+	for _, key := range bObject.Keys() {
+		bValue, _ := bObject.Get(key)
+		aValue, ok := aObject.Get(key)
+		if ok && parsing.Equal(aValue, bValue) {
+			continue
+		}
+		if bValue == nil {
+			return nil, fmt.Errorf("a merge patch cannot set %q to null", member(path, key))
+		}
+		if !ok {
+			aValue = nil
+		}
+		value, err := generateMerge(member(path, key), aValue, bValue)
+		if err != nil {
+			return nil, err
+		}
+		patch.Set(key, value)
+	}
+	return patch, nil
+}
+func verifyNoNulls(path pointer.Pointer, object *parsing.Object) error {
+	for _, item := range object.Members {
+		switch value := item.Value.(type) {
+		case nil:
+			return fmt.Errorf("a merge patch cannot set %q to null", member(path, item.Key))
+		case *parsing.Object:
+			if err := verifyNoNulls(member(path, item.Key), value); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,60 @@
+package patching
+
+import (
+	"testing"
+
+	"github.com/mdwhatcott/coding-challenges.fyi-json/lib/parsing"
+	"github.com/mdwhatcott/testing/should"
+)
+
+// Examples from RFC 7396, Appendix A.
+func TestMerge(t *testing.T) {
+	testMerge(t, `{"a":"b"}`, `{"a":"c"}`, `{"a":"c"}`)
+	testMerge(t, `{"a":"b"}`, `{"b":"c"}`, `{"a":"b","b":"c"}`)
+	testMerge(t, `{"a":"b"}`, `{"a":null}`, `{}`)
+	testMerge(t, `{"a":"b","b":"c"}`, `{"a":null}`, `{"b":"c"}`)
+	testMerge(t, `{"a":["b"]}`, `{"a":"c"}`, `{"a":"c"}`)
+	testMerge(t, `{"a":"c"}`, `{"a":["b"]}`, `{"a":["b"]}`)
+	testMerge(t, `{"a":{"b":"c"}}`, `{"a":{"b":"d","c":null}}`, `{"a":{"b":"d"}}`)
+	testMerge(t, `{"a":[{"b":"c"}]}`, `{"a":[1]}`, `{"a":[1]}`)
+	testMerge(t, `["a","b"]`, `["c","d"]`, `["c","d"]`)
+	testMerge(t, `{"a":"b"}`, `["c"]`, `["c"]`)
+	testMerge(t, `{"a":"foo"}`, `null`, `null`)
+	testMerge(t, `{"a":"foo"}`, `"bar"`, `"bar"`)
+	testMerge(t, `{"e":null}`, `{"a":1}`, `{"e":null,"a":1}`)
+	testMerge(t, `[1,2]`, `{"a":"b","c":null}`, `{"a":"b"}`)
+	testMerge(t, `{}`, `{"a":{"bb":{"ccc":null}}}`, `{"a":{"bb":{}}}`)
+}
+func TestMerge_PreservesTargetOrder(t *testing.T) {
+	testMerge(t, `{"z":1,"y":{"b":1,"a":2},"x":3}`, `{"x":4,"w":5,"y":{"a":null,"c":6,"b":7}}`,
+		`{"z":1,"y":{"b":7,"c":6},"x":4,"w":5}`)
+}
+func testMerge(t *testing.T, target, patch, expected string) {
+	t.Run(target+" "+patch, func(t *testing.T) {
+		original := parse(t, target)
+		should.So(t, compact(Merge(original, parse(t, patch))), should.Equal, expected)
+		should.So(t, parsing.Equal(original, parse(t, target)), should.BeTrue)
+	})
+}
+func TestGenerateMerge(t *testing.T) {
+	testGenerateMerge(t, `{"a":1}`, `{"a":1}`, `{}`)
+	testGenerateMerge(t, `{"a":1,"b":{"c":2,"d":3},"e":[1]}`, `{"b":{"c":2,"d":4},"e":[1,2],"f":true}`,
+		`{"a":null,"b":{"d":4},"e":[1,2],"f":true}`)
+	testGenerateMerge(t, `[1]`, `{"a":{"b":1}}`, `{"a":{"b":1}}`)
+	testGenerateMerge(t, `{"a":1}`, `"x"`, `"x"`)
+	testGenerateMerge(t, `{"a":null}`, `{"a":null,"b":1}`, `{"b":1}`)
+}
+func testGenerateMerge(t *testing.T, a, b, expected string) {
+	t.Run(a+" "+b, func(t *testing.T) {
+		patch, err := GenerateMerge(parse(t, a), parse(t, b))
+		should.So(t, err, should.BeNil)
+		should.So(t, compact(patch), should.Equal, expected)
+		should.So(t, parsing.Equal(Merge(parse(t, a), patch), parse(t, b)), should.BeTrue)
+	})
+}
+func TestGenerateMerge_Nulls(t *testing.T) {
+	_, err := GenerateMerge(parse(t, `{"a":{"b":1}}`), parse(t, `{"a":{"b":null}}`))
+	should.So(t, err.Error(), should.Equal, `a merge patch cannot set "/a/b" to null`)
+	_, err = GenerateMerge(parse(t, `{"a":1}`), parse(t, `{"a":{"b":{"c":null}}}`))
+	should.So(t, err.Error(), should.Equal, `a merge patch cannot set "/a/b/c" to null`)
+}
@@ -0,0 +1,127 @@
+package patching
+
+import (
+	"fmt"
+
+	"github.com/mdwhatcott/coding-challenges.fyi-json/lib/parsing"
+	"github.com/mdwhatcott/coding-challenges.fyi-json/lib/pointer"
+)
+
+// Operation is one step of a JSON Patch (RFC 6902).
+// From is used by "move" and "copy", Value by "add", "replace" and "test".
+type Operation struct {
+	Op    string
+	Path  pointer.Pointer
+	From  pointer.Pointer
+	Value any
+}
+
+func (this Operation) String() string {
+	if this.Op == "move" || this.Op == "copy" {
+		return fmt.Sprintf("%s %q -> %q", this.Op, this.From, this.Path)
+	}
+	return fmt.Sprintf("%s %q", this.Op, this.Path)
+}
+
+// ParseOperations interprets document (as produced by parsing.Parse) as an array of patch operations.
+func ParseOperations(document any) (operations []Operation, err error) {
+	array, ok := document.([]any)
+	if !ok {
+		return nil, fmt.Errorf("patch must be an array of operations, not %s", describe(document))
+	}
+	for x, element := range array {
+		operation, err := parseOperation(element)
+		if err != nil {
+			return nil, fmt.Errorf("operation %d: %w", x, err)
+		}
+		operations = append(operations, operation)
+	}
+	return operations, nil
+}
+func parseOperation(element any) (operation Operation, err error) {
+	object, ok := element.(*parsing.Object)
+	if !ok {
+		return operation, fmt.Errorf("must be an object, not %s", describe(element))
+	}
+	operation.Op, err = stringMember(object, "op")
+	if err != nil {
+		return operation, err
+	}
+	switch operation.Op {
+	case "add", "remove", "replace", "move", "copy", "test":
+	default:
+		return operation, fmt.Errorf("unknown op %q", operation.Op)
+	}
+	operation.Path, err = pointerMember(object, "path")
+	if err != nil {
+		return operation, err
+	}
+	switch operation.Op {
+	case "move", "copy":
+		operation.From, err = pointerMember(object, "from")
+		if err != nil {
+			return operation, err
+		}
+	case "add", "replace", "test":
+		value, ok := object.Get("value")
+		if !ok {
+			return operation, fmt.Errorf(`%s requires a "value" member`, operation.Op)
+		}
+		operation.Value = value
+	}
+	return operation, nil
+}
+func stringMember(object *parsing.Object, key string) (string, error) {
+	value, ok := object.Get(key)
+	if !ok {
+		return "", fmt.Errorf("missing %q member", key)
+	}
+	text, ok := value.(string)
+	if !ok {
+		return "", fmt.Errorf("%q member must be a string, not %s", key, describe(value))
+	}
+	return text, nil
+}
+func pointerMember(object *parsing.Object, key string) (pointer.Pointer, error) {
+	text, err := stringMember(object, key)
+	if err != nil {
+		return nil, err
+	}
+	return pointer.Parse(text)
+}
+
+// Value converts operations to a document, suitable for parsing.Render.
+func Value(operations []Operation) any {
+	result := []any{}
+	for _, operation := range operations {
+		object := &parsing.Object{}
+		object.Set("op", operation.Op)
+		if operation.From != nil {
+			object.Set("from", operation.From.String())
+		}
+		object.Set("path", operation.Path.String())
+		switch operation.Op {
+		case "add", "replace", "test":
+			object.Set("value", operation.Value)
+		}
+		result = append(result, object)
+	}
+	return result
+}
+
+func describe(value any) string {
+	switch value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "a boolean"
+	case parsing.Number:
+		return "a number"
+	case string:
+		return "a string"
+	case []any:
+		return "an array"
+	default:
+		return "an object"
+	}
+}
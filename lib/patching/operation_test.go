@@ -0,0 +1,57 @@
+package patching
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/mdwhatcott/coding-challenges.fyi-json/lib/parsing"
+	"github.com/mdwhatcott/coding-challenges.fyi-json/lib/pointer"
+	"github.com/mdwhatcott/coding-challenges.fyi-json/lib/printing"
+	"github.com/mdwhatcott/testing/should"
+)
+
+func TestParseOperations(t *testing.T) {
+	operations, err := ParseOperations(parse(t, `[
+		{"op": "add", "path": "/a/-", "value": null},
+		{"op": "remove", "path": "/b"},
+		{"op": "move", "from": "/c", "path": "/d~1e"},
+		{"op": "test", "path": "", "value": {}}
+	]`))
+	should.So(t, err, should.BeNil)
+	should.So(t, operations, should.Equal, []Operation{
+		{Op: "add", Path: pointer.Pointer{"a", "-"}, Value: nil},
+		{Op: "remove", Path: pointer.Pointer{"b"}},
+		{Op: "move", Path: pointer.Pointer{"d/e"}, From: pointer.Pointer{"c"}},
+		{Op: "test", Path: pointer.Pointer{}, Value: &parsing.Object{}},
+	})
+}
+func TestParseOperations_Invalid(t *testing.T) {
+	testInvalidOperations(t, `{}`, "patch must be an array of operations, not an object")
+	testInvalidOperations(t, `[1]`, "operation 0: must be an object, not a number")
+	testInvalidOperations(t, `[{"path": "/a"}]`, `operation 0: missing "op" member`)
+	testInvalidOperations(t, `[{"op": "frob", "path": "/a"}]`, `operation 0: unknown op "frob"`)
+	testInvalidOperations(t, `[{"op": "remove", "path": 1}]`, `operation 0: "path" member must be a string, not a number`)
+	testInvalidOperations(t, `[{"op": "remove", "path": "a"}]`, `operation 0: invalid JSON pointer (must be empty or start with '/'): "a"`)
+	testInvalidOperations(t, `[{"op": "remove", "path": ""}, {"op": "add", "path": "/a"}]`, `operation 1: add requires a "value" member`)
+	testInvalidOperations(t, `[{"op": "copy", "path": "/a"}]`, `operation 0: missing "from" member`)
+}
+func testInvalidOperations(t *testing.T, input, expected string) {
+	_, err := ParseOperations(parse(t, input))
+	should.So(t, err, should.NOT.BeNil)
+	should.So(t, err.Error(), should.Equal, expected)
+}
+func TestValue(t *testing.T) {
+	var out bytes.Buffer
+	parsing.Render(Value([]Operation{
+		{Op: "copy", Path: pointer.Pointer{"b"}, From: pointer.Pointer{"a"}},
+		{Op: "replace", Path: pointer.Pointer{}, Value: nil},
+	}), printing.NewCompactPrinter(&out))
+	should.So(t, out.String(), should.Equal, `[{"op":"copy","from":"/a","path":"/b"},{"op":"replace","path":"","value":null}]`)
+}
+func parse(t *testing.T, input string) any {
+	value, err := parsing.ParseBytes([]byte(input))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return value
+}
@@ -0,0 +1,77 @@
+package pointer
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Pointer is a parsed JSON Pointer (RFC 6901), one unescaped reference token per element.
+// The empty Pointer refers to the whole document.
+type Pointer []string
+
+func Parse(text string) (Pointer, error) {
+	if text == "" {
+		return Pointer{}, nil
+	}
+	if !strings.HasPrefix(text, "/") {
+		return nil, fmt.Errorf("invalid JSON pointer (must be empty or start with '/'): %q", text)
+	}
+	var result Pointer
+	for _, token := range strings.Split(text[1:], "/") {
+		if !validEscapes(token) {
+			return nil, fmt.Errorf("invalid JSON pointer (bad '~' escape): %q", text)
+		}
+		result = append(result, unescaper.Replace(token))
+	}
+	return result, nil
+}
+func validEscapes(token string) bool {
+	for x := 0; x < len(token); x++ {
+		if token[x] == '~' && (x+1 == len(token) || (token[x+1] != '0' && token[x+1] != '1')) {
+			return false
+		}
+	}
+	return true
+}
+
+func (this Pointer) String() string {
+	var builder strings.Builder
+	for _, token := range this {
+		builder.WriteString("/")
+		builder.WriteString(escaper.Replace(token))
+	}
+	return builder.String()
+}
+
+// Fragment returns the pointer in its URI fragment identifier representation
+// (RFC 6901, section 6), without the '#': characters that a fragment can't
+// contain (such as spaces, '%', '#' and non-ASCII characters) are percent-encoded.
+func (this Pointer) Fragment() string {
+	text := this.String()
+	var builder strings.Builder
+	for x := 0; x < len(text); x++ {
+		if c := text[x]; fragmentCharacter(c) {
+			builder.WriteByte(c)
+		} else {
+			_, _ = fmt.Fprintf(&builder, "%%%02X", c)
+		}
+	}
+	return builder.String()
+}
+
+// fragmentCharacter reports whether c may appear unencoded in a URI fragment (RFC 3986, section 3.5).
+func fragmentCharacter(c byte) bool {
+	return 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' || '0' <= c && c <= '9' || strings.IndexByte("-._~!$&'()*+,;=:@/?", c) >= 0
+}
+func (this Pointer) Equal(that Pointer) bool {
+	return slices.Equal(this, that)
+}
+func (this Pointer) HasPrefix(prefix Pointer) bool {
+	return len(this) >= len(prefix) && slices.Equal(this[:len(prefix)], prefix)
+}
+
+var (
+	escaper   = strings.NewReplacer("~", "~0", "/", "~1")
+	unescaper = strings.NewReplacer("~1", "/", "~0", "~")
+)
@@ -0,0 +1,44 @@
+package pointer
+
+import (
+	"testing"
+
+	"github.com/mdwhatcott/testing/should"
+)
+
+func TestParse(t *testing.T) {
+	testParse(t, "", Pointer{})
+	testParse(t, "/", Pointer{""})
+	testParse(t, "/foo/0", Pointer{"foo", "0"})
+	testParse(t, "/a~1b/m~0n", Pointer{"a/b", "m~n"})
+	testParse(t, "/~01", Pointer{"~1"})
+	testParse(t, "//", Pointer{"", ""})
+}
+func testParse(t *testing.T, input string, expected Pointer) {
+	t.Run(input, func(t *testing.T) {
+		actual, err := Parse(input)
+		should.So(t, err, should.BeNil)
+		should.So(t, actual, should.Equal, expected)
+		should.So(t, actual.String(), should.Equal, input)
+	})
+}
+func TestParseInvalid(t *testing.T) {
+	for _, input := range []string{"foo", "/~", "/~2", "/a~"} {
+		t.Run(input, func(t *testing.T) {
+			_, err := Parse(input)
+			should.So(t, err, should.NOT.BeNil)
+		})
+	}
+}
+func TestFragment(t *testing.T) {
+	should.So(t, Pointer{}.Fragment(), should.Equal, "")
+	should.So(t, Pointer{"a b", "c%d", "e#f", "é", "g/h", "i~j", "0"}.Fragment(), should.Equal, "/a%20b/c%25d/e%23f/%C3%A9/g~1h/i~0j/0")
+	should.So(t, Pointer{`"<'&>"`, "k=l?m:n@o"}.Fragment(), should.Equal, "/%22%3C'&%3E%22/k=l?m:n@o")
+}
+func TestHasPrefix(t *testing.T) {
+	should.So(t, Pointer{"a", "b"}.HasPrefix(Pointer{}), should.BeTrue)
+	should.So(t, Pointer{"a", "b"}.HasPrefix(Pointer{"a"}), should.BeTrue)
+	should.So(t, Pointer{"a", "b"}.HasPrefix(Pointer{"a", "b"}), should.BeTrue)
+	should.So(t, Pointer{"a"}.HasPrefix(Pointer{"a", "b"}), should.BeFalse)
+	should.So(t, Pointer{"a", "b"}.HasPrefix(Pointer{"b"}), should.BeFalse)
+}
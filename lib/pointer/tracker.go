@@ -0,0 +1,72 @@
+package pointer
+
+import (
+	"strconv"
+
+	"github.com/mdwhatcott/coding-challenges.fyi-json/lib/lexing"
+)
+
+// Step describes how a single token relates to the values of a document.
+type Step struct {
+	// Pointer locates the value begun and/or ended by the token. It is
+	// only valid until the next call to Tracker.Step.
+	Pointer Pointer
+	Begin   bool
+	End     bool
+}
+
+// Tracker follows a stream of (legal) tokens, keeping track of the
+// JSON Pointer of each value as it is begun and ended.
+type Tracker struct {
+	frames  []frame
+	current Pointer
+}
+
+type frame struct {
+	object      bool
+	index       int
+	awaitingKey bool
+}
+
+// Depth reports how many containers enclose the current token.
+func (this *Tracker) Depth() int {
+	return len(this.frames)
+}
+func (this *Tracker) Step(token lexing.Token) Step {
+	switch token.Type {
+	case lexing.TokenObjectStart, lexing.TokenArrayStart:
+		result := Step{Pointer: this.current, Begin: true}
+		object := token.Type == lexing.TokenObjectStart
+		this.frames = append(this.frames, frame{object: object, awaitingKey: object})
+		if object {
+			this.current = append(this.current, "")
+		} else {
+			this.current = append(this.current, "0")
+		}
+		return result
+	case lexing.TokenObjectStop, lexing.TokenArrayStop:
+		this.frames = this.frames[:len(this.frames)-1]
+		this.current = this.current[:len(this.current)-1]
+		return Step{Pointer: this.current, End: true}
+	case lexing.TokenComma:
+		top := &this.frames[len(this.frames)-1]
+		if top.object {
+			top.awaitingKey = true
+		} else {
+			top.index++
+			this.current[len(this.current)-1] = strconv.Itoa(top.index)
+		}
+		return Step{}
+	case lexing.TokenString:
+		if len(this.frames) > 0 && this.frames[len(this.frames)-1].awaitingKey {
+			this.frames[len(this.frames)-1].awaitingKey = false
+			this.current[len(this.current)-1] = lexing.Unquote(token.Value)
+			return Step{}
+		}
+		return Step{Pointer: this.current, Begin: true, End: true}
+	case lexing.TokenNumber, lexing.TokenTrue, lexing.TokenFalse, lexing.TokenNull:
+		return Step{Pointer: this.current, Begin: true, End: true}
+	default:
+		return Step{}
+	}
+}
@@ -0,0 +1,41 @@
+package pointer
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/mdwhatcott/coding-challenges.fyi-json/lib/lexing"
+	"github.com/mdwhatcott/testing/should"
+)
+
+func TestTracker(t *testing.T) {
+	var (
+		tracker Tracker
+		actual  []string
+	)
+	input := `{"a": [1, {"b/c": null}], "d": {}}`
+	for token := range lexing.Lex(strings.NewReader(input)) {
+		step := tracker.Step(token)
+		if step.Begin {
+			actual = append(actual, "begin "+step.Pointer.String())
+		}
+		if step.End {
+			actual = append(actual, "end "+step.Pointer.String())
+		}
+	}
+	should.So(t, actual, should.Equal, []string{
+		"begin ",
+		"begin /a",
+		"begin /a/0",
+		"end /a/0",
+		"begin /a/1",
+		"begin /a/1/b~1c",
+		"end /a/1/b~1c",
+		"end /a/1",
+		"end /a",
+		"begin /d",
+		"end /d",
+		"end ",
+	})
+	should.So(t, tracker.Depth(), should.Equal, 0)
+}
@@ -0,0 +1,234 @@
+package printing
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+
+	"github.com/mdwhatcott/coding-challenges.fyi-json/lib/lexing"
+)
+
+// canonical writes the JSON Canonicalization Scheme (RFC 8785): no whitespace,
+// object members sorted by the UTF-16 code units of their keys, numbers as
+// ECMAScript would serialize them, and strings with minimal escaping.
+// Each object is buffered until it is complete so its members can be sorted.
+// Documents with numbers beyond the range of doubles (too large, or too small
+// to be told from zero), strings with lone surrogates, or duplicate keys have
+// no canonical form: the first such problem is reported by Err (and ends the
+// output).
+type canonical struct {
+	out    io.Writer
+	frames []*canonicalFrame
+	err    error
+}
+
+var (
+	ErrNumberOutOfRange = errors.New("number out of the range of IEEE 754 doubles")
+	ErrDuplicateKey     = errors.New("duplicate object key")
+	ErrLoneSurrogate    = errors.New("lone surrogate")
+)
+
+type canonicalFrame struct {
+	object    bool
+	expectKey bool
+	member    canonicalMember
+	members   []canonicalMember
+}
+
+type canonicalMember struct {
+	key  []uint16
+	text bytes.Buffer
+}
+
+func NewCanonicalPrinter(out io.Writer) *canonical {
+	return &canonical{out: out}
+}
+
+// Err reports why the input has no canonical form, if it hasn't.
+func (this *canonical) Err() error {
+	return this.err
+}
+
+func (this *canonical) Print(token lexing.Token) {
+	if this.err != nil {
+		return
+	}
+	switch token.Type {
+	case lexing.TokenWhitespace:
+	case lexing.TokenObjectStart:
+		this.frames = append(this.frames, &canonicalFrame{object: true, expectKey: true})
+	case lexing.TokenObjectStop:
+		frame := this.pop()
+		frame.finishMember()
+		slices.SortStableFunc(frame.members, func(a, b canonicalMember) int {
+			return slices.Compare(a.key, b.key)
+		})
+		for x := 1; x < len(frame.members); x++ {
+			if key := frame.members[x].key; slices.Equal(key, frame.members[x-1].key) {
+				this.err = fmt.Errorf("can't canonicalize: %w: %q", ErrDuplicateKey, string(utf16.Decode(key)))
+				return
+			}
+		}
+		this.write([]byte("{"))
+		for x, member := range frame.members {
+			if x > 0 {
+				this.write([]byte(","))
+			}
+			this.write(member.text.Bytes())
+		}
+		this.write([]byte("}"))
+	case lexing.TokenArrayStart:
+		this.write(token.Value)
+		this.frames = append(this.frames, &canonicalFrame{})
+	case lexing.TokenArrayStop:
+		this.pop()
+		this.write(token.Value)
+	case lexing.TokenComma:
+		if frame := this.top(); frame != nil && frame.object {
+			frame.finishMember()
+			frame.expectKey = true
+		} else {
+			this.write(token.Value)
+		}
+	case lexing.TokenString:
+		if escape, ok := loneSurrogate(token.Value); ok {
+			this.err = fmt.Errorf("can't canonicalize: %w: %s", ErrLoneSurrogate, escape)
+			return
+		}
+		value := lexing.Unquote(token.Value)
+		if frame := this.top(); frame != nil && frame.object && frame.expectKey {
+			frame.member.key = utf16.Encode([]rune(value))
+			frame.expectKey = false
+		}
+		this.write(lexing.Quote(value))
+	case lexing.TokenNumber:
+		number, ok := canonicalNumber(token.Value)
+		if !ok {
+			this.err = fmt.Errorf("can't canonicalize: %w: %s", ErrNumberOutOfRange, token.Value)
+			return
+		}
+		this.write(number)
+	default:
+		this.write(token.Value)
+	}
+}
+func (this *canonical) top() *canonicalFrame {
+	if len(this.frames) == 0 {
+		return nil
+	}
+	return this.frames[len(this.frames)-1]
+}
+func (this *canonical) pop() *canonicalFrame {
+	frame := this.top()
+	if frame != nil {
+		this.frames = this.frames[:len(this.frames)-1]
+	}
+	return frame
+}
+
+// write appends to the member being buffered by the innermost object, if any.
+func (this *canonical) write(data []byte) {
+	for x := len(this.frames) - 1; x >= 0; x-- {
+		if this.frames[x].object {
+			this.frames[x].member.text.Write(data)
+			return
+		}
+	}
+	_, _ = this.out.Write(data)
+}
+func (this *canonicalFrame) finishMember() {
+	if this.member.text.Len() > 0 {
+		this.members = append(this.members, this.member)
+	}
+	this.member = canonicalMember{}
+}
+
+// loneSurrogate finds the first escaped surrogate (such as \uD800) of the string
+// token that isn't part of a pair, which Unquote would decode as U+FFFD.
+func loneSurrogate(token []byte) (escape []byte, found bool) {
+	for x := 0; x < len(token); x++ {
+		if token[x] != '\\' {
+			continue
+		}
+		x++
+		r, ok := escapedSurrogate(token[x:])
+		if !ok {
+			continue
+		}
+		if r >= 0xDC00 {
+			return token[x-1 : x+5], true
+		}
+		if low, ok := escapedSurrogate(token[min(x+6, len(token)):]); !ok || low < 0xDC00 || token[x+5] != '\\' {
+			return token[x-1 : x+5], true
+		}
+		x += 10
+	}
+	return nil, false
+}
+
+// escapedSurrogate decodes the surrogate escaped as uXXXX (after the backslash) at the start of text.
+func escapedSurrogate(text []byte) (rune, bool) {
+	if len(text) < 5 || text[0] != 'u' {
+		return 0, false
+	}
+	n, err := strconv.ParseUint(string(text[1:5]), 16, 32)
+	return rune(n), err == nil && utf16.IsSurrogate(rune(n))
+}
+
+// canonicalNumber formats a number like ECMAScript's Number.prototype.toString,
+// unless it is beyond the range of a double (and can't be canonicalized): too
+// large, or so small that it would become zero.
+func canonicalNumber(raw []byte) ([]byte, bool) {
+	value, err := strconv.ParseFloat(string(raw), 64)
+	if err != nil || math.IsInf(value, 0) {
+		return nil, false
+	}
+	if value == 0 {
+		mantissa, _, _ := bytes.Cut(raw, []byte("e"))
+		mantissa, _, _ = bytes.Cut(mantissa, []byte("E"))
+		return []byte("0"), !bytes.ContainsAny(mantissa, "123456789")
+	}
+	var builder strings.Builder
+	if value < 0 {
+		builder.WriteByte('-')
+		value = -value
+	}
+	// Shortest round-trip digits, as d.ddde±x, become digits and decimal point position n.
+	scientific := strconv.FormatFloat(value, 'e', -1, 64)
+	mantissa, exponent, _ := strings.Cut(scientific, "e")
+	digits := strings.Replace(mantissa, ".", "", 1)
+	e, _ := strconv.Atoi(exponent)
+	n := e + 1
+	k := len(digits)
+	switch {
+	case k <= n && n <= 21:
+		builder.WriteString(digits)
+		builder.WriteString(strings.Repeat("0", n-k))
+	case 0 < n && n <= 21:
+		builder.WriteString(digits[:n])
+		builder.WriteByte('.')
+		builder.WriteString(digits[n:])
+	case -6 < n && n <= 0:
+		builder.WriteString("0.")
+		builder.WriteString(strings.Repeat("0", -n))
+		builder.WriteString(digits)
+	default:
+		builder.WriteString(digits[:1])
+		if k > 1 {
+			builder.WriteByte('.')
+			builder.WriteString(digits[1:])
+		}
+		builder.WriteByte('e')
+		if n-1 >= 0 {
+			builder.WriteByte('+')
+		}
+		builder.WriteString(strconv.Itoa(n - 1))
+	}
+	return []byte(builder.String()), true
+}
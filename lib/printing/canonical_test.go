@@ -0,0 +1,97 @@
+package printing
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/mdwhatcott/coding-challenges.fyi-json/lib/lexing"
+	"github.com/mdwhatcott/testing/should"
+)
+
+func TestCanonicalPrinter(t *testing.T) {
+	// Adapted from RFC 8785, section 3.2.3 (sorting) and 3.2.2 (values).
+	testCanonical(t, `{
+		"€": "Euro Sign",
+		"\r": "Carriage Return",
+		"דּ": "Hebrew Letter Dalet With Dagesh",
+		"1": "One",
+		"😀": "Emoji: Grinning Face",
+		"\u0080": "Control",
+		"ö": "Latin Small Letter O With Diaeresis"
+	}`, `{"\r":"Carriage Return","1":"One","`+"\u0080"+`":"Control","ö":"Latin Small Letter O With Diaeresis","€":"Euro Sign","😀":"Emoji: Grinning Face","`+"\ufb33"+`":"Hebrew Letter Dalet With Dagesh"}`)
+	testCanonical(t, `{
+		"numbers": [333333333.33333329, 1E30, 4.50, 2e-3, 0.000000000000000000000000001],
+		"string": "\u20ac$\u000F\u000aA'\u0042\u0022\u005c\\\"\/",
+		"literals": [null, true, false]
+	}`, `{"literals":[null,true,false],"numbers":[333333333.3333333,1e+30,4.5,0.002,1e-27],"string":"€$\u000f\nA'B\"\\\\\"/"}`)
+	testCanonical(t, `[{"b": [{"d": 1, "c": 2}], "a": {}}, {"z": [], "y": [{}]}]`, `[{"a":{},"b":[{"c":2,"d":1}]},{"y":[{}],"z":[]}]`)
+	testCanonical(t, ` "top" `, `"top"`)
+}
+func testCanonical(t *testing.T, input, expected string) {
+	out := &bytes.Buffer{}
+	printer := NewCanonicalPrinter(out)
+	for token := range lexing.Lex(strings.NewReader(input)) {
+		printer.Print(token)
+	}
+	should.So(t, printer.Err(), should.BeNil)
+	should.So(t, out.String(), should.Equal, expected)
+}
+func TestCanonicalPrinter_Errors(t *testing.T) {
+	testCanonicalError(t, `[1, 1e400]`, ErrNumberOutOfRange, `can't canonicalize: number out of the range of IEEE 754 doubles: 1e400`)
+	testCanonicalError(t, `-1e400`, ErrNumberOutOfRange, `can't canonicalize: number out of the range of IEEE 754 doubles: -1e400`)
+	testCanonicalError(t, `{"a": 1, "b": {"c": 2, "\u0063": 3}}`, ErrDuplicateKey, `can't canonicalize: duplicate object key: "c"`)
+	testCanonicalError(t, `{"a": 1, "a": 0}`, ErrDuplicateKey, `can't canonicalize: duplicate object key: "a"`)
+	testCanonicalError(t, `[1e-400]`, ErrNumberOutOfRange, `can't canonicalize: number out of the range of IEEE 754 doubles: 1e-400`)
+	testCanonicalError(t, `["\uD800"]`, ErrLoneSurrogate, `can't canonicalize: lone surrogate: \uD800`)
+	testCanonicalError(t, `{"a\udc00b": 1}`, ErrLoneSurrogate, `can't canonicalize: lone surrogate: \udc00`)
+	testCanonicalError(t, `"\uD834\uD834\uDD1E"`, ErrLoneSurrogate, `can't canonicalize: lone surrogate: \uD834`)
+	testCanonicalError(t, `"\uD834\n"`, ErrLoneSurrogate, `can't canonicalize: lone surrogate: \uD834`)
+}
+func TestCanonicalPrinter_SurrogatePairs(t *testing.T) {
+	out := &bytes.Buffer{}
+	printer := NewCanonicalPrinter(out)
+	for token := range lexing.Lex(strings.NewReader(`["\uD834\uDD1E", "\\uD800", "\uFFFD"]`)) {
+		printer.Print(token)
+	}
+	should.So(t, printer.Err(), should.BeNil)
+	should.So(t, out.String(), should.Equal, `["𝄞","\\uD800","�"]`)
+}
+func testCanonicalError(t *testing.T, input string, expected error, message string) {
+	t.Run(input, func(t *testing.T) {
+		printer := NewCanonicalPrinter(&bytes.Buffer{})
+		for token := range lexing.Lex(strings.NewReader(input)) {
+			printer.Print(token)
+		}
+		should.So(t, printer.Err(), should.WrapError, expected)
+		should.So(t, printer.Err().Error(), should.Equal, message)
+	})
+}
+func TestCanonicalNumber(t *testing.T) {
+	for raw, expected := range map[string]string{
+		"0":                       "0",
+		"0e-999":                  "0",
+		"-0.000E+5":               "0",
+		"-0":                      "0",
+		"1":                       "1",
+		"-1.50":                   "-1.5",
+		"1e21":                    "1e+21",
+		"1e20":                    "100000000000000000000",
+		"123456789012345678901":   "123456789012345680000",
+		"0.000001":                "0.000001",
+		"0.0000001":               "1e-7",
+		"1.7976931348623157e308":  "1.7976931348623157e+308",
+		"5e-324":                  "5e-324",
+		"9007199254740993":        "9007199254740992",
+		"295147905179352830000":   "295147905179352830000",
+		"-1.2345678901234567e-10": "-1.2345678901234568e-10",
+	} {
+		actual, ok := canonicalNumber([]byte(raw))
+		should.So(t, ok, should.BeTrue)
+		should.So(t, string(actual), should.Equal, expected)
+	}
+	for _, raw := range []string{"1e400", "1e-400", "-2e-324", "0.0001E-999"} {
+		_, ok := canonicalNumber([]byte(raw))
+		should.So(t, ok, should.BeFalse)
+	}
+}
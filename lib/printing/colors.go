@@ -2,59 +2,117 @@ package printing
 
 import (
 	"io"
+	"slices"
 
 	"github.com/mdwhatcott/coding-challenges.fyi-json/lib/lexing"
 )
 
+// colors surrounds each token printed by the inner printer with ANSI escape
+// codes, either writing them to out itself, or (without out) wrapping the
+// value of each token in them before passing it on.
 type colors struct {
 	out   io.Writer
 	inner Printer
+	theme Theme
+	stack []lexing.TokenType // open containers, for coloring commas, colons and keys
+	key   bool               // whether the next string is an object key
 }
 
-func NewColorPrinter(out io.Writer, inner Printer) *colors {
-	return &colors{out: out, inner: inner}
+// NewColorPrinter writes the escape codes to out, around each token printed
+// by inner, which must write each token to out as soon as it is printed.
+func NewColorPrinter(out io.Writer, inner Printer, options ...ColorOption) *colors {
+	return newColors(out, inner, options)
+}
+
+// NewInlineColorPrinter wraps the value of each token in escape codes for
+// inner to write, so that printers that buffer tokens or write whitespace of
+// their own (such as the pretty and sorting printers) keep each token
+// together with its color.
+func NewInlineColorPrinter(inner Printer, options ...ColorOption) *colors {
+	return newColors(nil, inner, options)
+}
+func newColors(out io.Writer, inner Printer, options []ColorOption) *colors {
+	this := &colors{out: out, inner: inner, theme: DefaultTheme}
+	for _, option := range options {
+		option(this)
+	}
+	return this
+}
+
+type ColorOption func(*colors)
+
+func ColorTheme(theme Theme) ColorOption {
+	return func(this *colors) { this.theme = theme }
 }
 
 func (this *colors) Print(token lexing.Token) {
 	switch token.Type {
 	case lexing.TokenNull:
-		this.write(gray, token)
+		this.write(this.theme.Null, token)
 	case lexing.TokenTrue:
-		this.write(green, token)
+		this.write(this.theme.True, token)
 	case lexing.TokenFalse:
-		this.write(purple, token)
+		this.write(this.theme.False, token)
 	case lexing.TokenNumber:
-		this.write(yellow, token)
+		this.write(this.theme.Number, token)
 	case lexing.TokenString:
-		this.write(blue, token)
-	case lexing.TokenArrayStart,
-		lexing.TokenArrayStop,
-		lexing.TokenObjectStart,
-		lexing.TokenObjectStop,
-		lexing.TokenComma,
-		lexing.TokenColon:
-		this.write(cyan, token)
+		if this.key {
+			this.write(this.theme.Key, token)
+		} else {
+			this.write(this.theme.String, token)
+		}
+	case lexing.TokenArrayStart, lexing.TokenObjectStart:
+		this.stack = append(this.stack, token.Type)
+		this.write(this.containerColor(), token)
+	case lexing.TokenArrayStop, lexing.TokenObjectStop:
+		this.write(this.containerColor(), token)
+		if len(this.stack) > 0 {
+			this.stack = this.stack[:len(this.stack)-1]
+		}
+	case lexing.TokenComma, lexing.TokenColon:
+		this.write(this.containerColor(), token)
 	case lexing.TokenIllegal:
-		this.write(red, token)
+		this.write(this.theme.Illegal, token)
 	default:
 		this.inner.Print(token)
 	}
+	if token.Type != lexing.TokenWhitespace {
+		this.key = token.Type == lexing.TokenObjectStart || (token.Type == lexing.TokenComma && this.inObject())
+	}
+}
+func (this *colors) inObject() bool {
+	return len(this.stack) > 0 && this.stack[len(this.stack)-1] == lexing.TokenObjectStart
+}
+func (this *colors) containerColor() []byte {
+	if this.inObject() {
+		return this.theme.Object
+	}
+	return this.theme.Array
 }
 
 func (this *colors) write(color []byte, token lexing.Token) {
-	_, _ = this.out.Write(color)
-	this.inner.Print(token)
-	_, _ = this.out.Write(reset)
+	if len(color) == 0 {
+		this.inner.Print(token)
+		return
+	}
+	if this.out != nil {
+		_, _ = this.out.Write(color)
+		this.inner.Print(token)
+		_, _ = this.out.Write(Reset)
+		return
+	}
+	this.inner.Print(lexing.Token{Type: token.Type, Value: slices.Concat(color, token.Value, Reset)})
 }
 
+// The ANSI escape codes of the default theme, also used for other colored output.
 var (
-	reset  = []byte("\033[0m")
-	red    = []byte("\033[31m")
-	green  = []byte("\033[32m")
-	yellow = []byte("\033[33m")
-	blue   = []byte("\033[34m")
-	purple = []byte("\033[35m")
-	cyan   = []byte("\033[36m")
-	gray   = []byte("\033[37m")
-	white  = []byte("\033[97m")
+	Reset  = []byte("\033[0m")
+	Red    = []byte("\033[31m")
+	Green  = []byte("\033[32m")
+	Yellow = []byte("\033[33m")
+	Blue   = []byte("\033[34m")
+	Purple = []byte("\033[35m")
+	Cyan   = []byte("\033[36m")
+	Gray   = []byte("\033[37m")
+	White  = []byte("\033[97m")
 )
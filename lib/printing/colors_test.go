@@ -29,3 +29,22 @@ func (this *ColorsSuite) Test() {
 		"\x1b[36m{\x1b[0m\x1b[34m\"a\"\x1b[0m\x1b[36m:\x1b[0m \x1b[36m[\x1b[0m\x1b[33m1\x1b[0m\x1b[36m,\x1b[0m\x1b[33m2\x1b[0m\x1b[36m,\x1b[0m\x1b[33m3\x1b[0m\x1b[36m,\x1b[0m\x1b[37mnull\x1b[0m\x1b[36m,\x1b[0m\x1b[32mtrue\x1b[0m\x1b[36m,\x1b[0m\x1b[35mfalse\x1b[0m \x1b[36m]\x1b[0m\x1b[36m,\x1b[0m\x1b[34m\"b\"\x1b[0m\x1b[36m:\x1b[0m\x1b[34m\"hi\"\x1b[0m \x1b[36m}\x1b[0m\x1b[31masdf\x1b[0m",
 	)
 }
+
+func (this *ColorsSuite) TestTheme() {
+	var out bytes.Buffer
+	theme := Theme{Key: []byte("<k>"), String: []byte("<s>"), Array: []byte("<a>"), Object: []byte("<o>")}
+	outer := NewInlineColorPrinter(NewVerbatimPrinter(&out), ColorTheme(theme))
+	for token := range lexing.Lex(strings.NewReader(`{"a": ["b", {"c": "d"}], "e": 1}`)) {
+		outer.Print(token)
+	}
+	this.So(strings.ReplaceAll(out.String(), string(Reset), "|"), should.Equal,
+		`<o>{|<k>"a"|<o>:| <a>[|<s>"b"|<a>,| <o>{|<k>"c"|<o>:| <s>"d"|<o>}|<a>]|<o>,| <k>"e"|<o>:| 1`+"<o>}|")
+}
+func (this *ColorsSuite) TestMonochrome() {
+	var out bytes.Buffer
+	outer := NewColorPrinter(&out, NewCompactPrinter(&out), ColorTheme(MonochromeTheme))
+	for token := range lexing.Lex(strings.NewReader(`{"a": [null]}`)) {
+		outer.Print(token)
+	}
+	this.So(out.String(), should.Equal, "\x1b[1m{\x1b[0m\x1b[1m\"a\"\x1b[0m\x1b[1m:\x1b[0m\x1b[1m[\x1b[0mnull\x1b[1m]\x1b[0m\x1b[1m}\x1b[0m")
+}
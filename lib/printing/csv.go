@@ -0,0 +1,255 @@
+package printing
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/mdwhatcott/coding-challenges.fyi-json/lib/lexing"
+)
+
+var ErrNotTabular = errors.New("CSV output requires a top-level array of objects")
+
+// csvPrinter prints a top-level array of objects as CSV (RFC 4180): a header row of
+// column names, then one row per object, written as soon as the object is
+// complete. Each cell holds the decoded value of the member with the column's
+// key: strings without quotes, null (like a missing member) as an empty cell,
+// and nested arrays and objects as compact JSON. Other input isn't tabular
+// and is reported by Err (and ends the output).
+type csvPrinter struct {
+	out      io.Writer
+	writer   *csv.Writer
+	columns  []string
+	indexes  map[string]int
+	buffered []lexing.Token // the whole document, until the columns are known
+	open     int            // the number of containers open in buffered
+
+	depth  int
+	row    []string
+	column int    // of the member whose value is next (-1 when unknown)
+	nested []byte // the compact JSON of a nested value, so far
+	key    bool
+	rows   int
+	err    error
+}
+
+// NewCSVPrinter streams rows under the given columns (see CSVColumns), or, when
+// there are none, buffers the document to find its columns before writing anything.
+func NewCSVPrinter(out io.Writer, options ...CSVOption) *csvPrinter {
+	this := &csvPrinter{out: out, writer: csv.NewWriter(out), column: -1}
+	for _, option := range options {
+		option(this)
+	}
+	return this
+}
+
+type CSVOption func(*csvPrinter)
+
+// CSVColumns sets the columns up front (keys of other members are ignored).
+func CSVColumns(columns []string) CSVOption {
+	return func(this *csvPrinter) { this.setColumns(columns) }
+}
+
+// CSVComma separates the cells of each row with comma, such as '\t' for TSV (default ',').
+func CSVComma(comma rune) CSVOption {
+	return func(this *csvPrinter) { this.writer.Comma = comma }
+}
+
+// CSVCRLF ends rows with "\r\n" rather than "\n".
+func CSVCRLF() CSVOption {
+	return func(this *csvPrinter) { this.writer.UseCRLF = true }
+}
+
+// Err reports why the input couldn't be written as CSV, if it couldn't.
+func (this *csvPrinter) Err() error {
+	return this.err
+}
+
+func (this *csvPrinter) Print(token lexing.Token) {
+	if this.err != nil || token.Type == lexing.TokenWhitespace {
+		return
+	}
+	if this.indexes == nil {
+		this.buffer(token)
+		return
+	}
+	if token.Type == lexing.TokenIllegal {
+		this.fail(fmt.Errorf("illegal token: %s", token.Value))
+		return
+	}
+	switch this.depth {
+	case 0:
+		this.begin(token)
+	case 1:
+		this.element(token)
+	case 2:
+		this.member(token)
+	default:
+		this.nest(token)
+	}
+}
+func (this *csvPrinter) buffer(token lexing.Token) {
+	this.buffered = append(this.buffered, token)
+	switch token.Type {
+	case lexing.TokenObjectStart, lexing.TokenArrayStart:
+		this.open++
+	case lexing.TokenObjectStop, lexing.TokenArrayStop:
+		this.open--
+	}
+	if this.open > 0 && token.Type != lexing.TokenIllegal {
+		return
+	}
+	this.setColumns(columns(this.buffered))
+	for _, token := range this.buffered {
+		this.Print(token)
+	}
+	this.buffered = nil
+}
+func (this *csvPrinter) setColumns(columns []string) {
+	this.columns = columns
+	this.indexes = make(map[string]int)
+	for x, column := range columns {
+		if _, ok := this.indexes[column]; !ok {
+			this.indexes[column] = x
+		}
+	}
+}
+
+// begin writes the header at the start of the top-level array.
+func (this *csvPrinter) begin(token lexing.Token) {
+	if token.Type != lexing.TokenArrayStart {
+		this.fail(ErrNotTabular)
+		return
+	}
+	this.depth++
+	if len(this.columns) > 0 {
+		this.write(this.columns)
+	}
+}
+func (this *csvPrinter) element(token lexing.Token) {
+	switch token.Type {
+	case lexing.TokenObjectStart:
+		this.depth++
+		this.row = make([]string, len(this.columns))
+		this.key = true
+	case lexing.TokenArrayStop:
+		this.depth--
+	case lexing.TokenComma:
+	default:
+		this.fail(fmt.Errorf("%w (element %d is not an object)", ErrNotTabular, this.rows))
+	}
+}
+func (this *csvPrinter) member(token lexing.Token) {
+	switch token.Type {
+	case lexing.TokenObjectStop:
+		this.depth--
+		if len(this.columns) > 0 {
+			this.write(this.row)
+		}
+		this.rows++
+	case lexing.TokenComma:
+		this.key = true
+	case lexing.TokenColon:
+	case lexing.TokenObjectStart, lexing.TokenArrayStart:
+		this.depth++
+		this.nested = append(this.nested[:0], token.Value...)
+	case lexing.TokenString:
+		if this.key {
+			this.key = false
+			this.column = -1
+			if x, ok := this.indexes[lexing.Unquote(token.Value)]; ok {
+				this.column = x
+			}
+			return
+		}
+		this.cell(lexing.Unquote(token.Value))
+	case lexing.TokenNull:
+		this.cell("")
+	default:
+		this.cell(string(token.Value))
+	}
+}
+
+// nest collects the compact JSON of a nested array or object.
+func (this *csvPrinter) nest(token lexing.Token) {
+	this.nested = append(this.nested, token.Value...)
+	switch token.Type {
+	case lexing.TokenObjectStart, lexing.TokenArrayStart:
+		this.depth++
+	case lexing.TokenObjectStop, lexing.TokenArrayStop:
+		if this.depth--; this.depth == 2 {
+			this.cell(string(this.nested))
+		}
+	}
+}
+func (this *csvPrinter) cell(value string) {
+	if this.column >= 0 {
+		this.row[this.column] = value
+	}
+}
+func (this *csvPrinter) write(record []string) {
+	if len(record) == 1 && record[0] == "" {
+		// encoding/csv would write an empty line, which readers skip.
+		record = nil
+		_, _ = io.WriteString(this.out, `""`)
+	}
+	_ = this.writer.Write(record)
+	this.writer.Flush()
+	if err := this.writer.Error(); err != nil {
+		this.fail(err)
+	}
+}
+func (this *csvPrinter) fail(err error) {
+	this.err = err
+	this.buffered = nil
+}
+
+// ReadCSVColumns lists the keys of the objects in a top-level array, in the
+// order they first appear, for streaming a second pass over the same input.
+func ReadCSVColumns(input io.Reader) []string {
+	var collector columnCollector
+	for token := range lexing.Lex(input) {
+		collector.add(token)
+	}
+	return collector.columns
+}
+func columns(tokens []lexing.Token) []string {
+	var collector columnCollector
+	for _, token := range tokens {
+		collector.add(token)
+	}
+	return collector.columns
+}
+
+type columnCollector struct {
+	depth   int
+	key     bool
+	seen    map[string]bool
+	columns []string
+}
+
+func (this *columnCollector) add(token lexing.Token) {
+	switch token.Type {
+	case lexing.TokenObjectStart:
+		this.depth++
+		this.key = this.depth == 2
+	case lexing.TokenArrayStart:
+		this.depth++
+	case lexing.TokenObjectStop, lexing.TokenArrayStop:
+		this.depth--
+	case lexing.TokenComma:
+		this.key = this.depth == 2
+	case lexing.TokenString:
+		if this.key {
+			this.key = false
+			if key := lexing.Unquote(token.Value); !this.seen[key] {
+				if this.seen == nil {
+					this.seen = make(map[string]bool)
+				}
+				this.seen[key] = true
+				this.columns = append(this.columns, key)
+			}
+		}
+	}
+}
@@ -0,0 +1,68 @@
+package printing
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/mdwhatcott/coding-challenges.fyi-json/lib/lexing"
+	"github.com/mdwhatcott/testing/should"
+)
+
+func TestCSVPrinter(t *testing.T) {
+	testCSV(t, `[{"a": 1, "b": "x"}, {"c": true, "a": null}, {"b": "y, \"z\"\n", "d": {"e": [1, {}]}}]`, ""+
+		"a,b,c,d\n"+
+		"1,x,,\n"+
+		",,true,\n"+
+		",\"y, \"\"z\"\"\n\",,\"{\"\"e\"\":[1,{}]}\"\n")
+	testCSV(t, `[{"ünïé": "é"}, {}]`, "ünïé\né\n\"\"\n")
+	testCSV(t, `[]`, "")
+	testCSV(t, `[{}]`, "")
+	testCSV(t, `[{"a": 1, "a": 2}]`, "a\n2\n")
+}
+func TestCSVPrinter_Options(t *testing.T) {
+	testCSV(t, `[{"a": 1, "b": "x\ty"}]`, "a\tb\r\n1\t\"x\ty\"\r\n", CSVComma('\t'), CSVCRLF())
+	testCSV(t, `[{"a": 1, "b": 2, "c": 3}]`, "c,a,z\n3,1,\n", CSVColumns([]string{"c", "a", "z"}))
+	testCSV(t, `[]`, "c\n", CSVColumns([]string{"c"}))
+}
+func testCSV(t *testing.T, input, expected string, options ...CSVOption) {
+	t.Run(input, func(t *testing.T) {
+		out := &bytes.Buffer{}
+		printer := NewCSVPrinter(out, options...)
+		for token := range lexing.Lex(strings.NewReader(input)) {
+			printer.Print(token)
+		}
+		should.So(t, printer.Err(), should.BeNil)
+		should.So(t, out.String(), should.Equal, expected)
+	})
+}
+func TestCSVPrinter_Streaming(t *testing.T) {
+	out := &bytes.Buffer{}
+	printer := NewCSVPrinter(out, CSVColumns([]string{"a"}))
+	for token := range lexing.Lex(strings.NewReader(`[{"a": 1}, {"a": 2`)) {
+		printer.Print(token)
+	}
+	should.So(t, out.String(), should.Equal, "a\n1\n")
+}
+func TestCSVPrinter_Errors(t *testing.T) {
+	testCSVError(t, `{"a": 1}`, "", "CSV output requires a top-level array of objects")
+	testCSVError(t, `3`, "", "CSV output requires a top-level array of objects")
+	testCSVError(t, `[{"a": 1}, 2, {"a": 3}]`, "a\n1\n", "CSV output requires a top-level array of objects (element 1 is not an object)")
+	testCSVError(t, `[{"a": 1}, {"a": tru`, "a\n1\n", "illegal token: tru")
+}
+func testCSVError(t *testing.T, input, expected, message string) {
+	t.Run(input, func(t *testing.T) {
+		out := &bytes.Buffer{}
+		printer := NewCSVPrinter(out)
+		for token := range lexing.Lex(strings.NewReader(input)) {
+			printer.Print(token)
+		}
+		should.So(t, printer.Err(), should.NOT.BeNil)
+		should.So(t, printer.Err().Error(), should.Equal, message)
+		should.So(t, out.String(), should.Equal, expected)
+	})
+}
+func TestReadCSVColumns(t *testing.T) {
+	columns := ReadCSVColumns(strings.NewReader(`[{"a": {"x": 1}, "b": [{"y": 2}]}, {"c": 3, "a": 4}, 5, [{"z": 6}]]`))
+	should.So(t, columns, should.Equal, []string{"a", "b", "c"})
+}
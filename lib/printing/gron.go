@@ -0,0 +1,107 @@
+package printing
+
+import (
+	"io"
+	"strconv"
+	"unicode"
+
+	"github.com/mdwhatcott/coding-challenges.fyi-json/lib/lexing"
+)
+
+// gron prints one assignment statement per value, in the style of the gron
+// tool: `json.users[0].name = "bob";`. Arrays and objects are assigned as
+// empty (`[]`, `{}`) before their contents, so the document can be rebuilt
+// from the statements (even just some of them).
+type gron struct {
+	out         io.Writer
+	frames      []gronFrame
+	awaitingKey bool
+	key         []byte // the accessor of the member whose value is next
+	started     bool
+}
+
+type gronFrame struct {
+	path  []byte
+	array bool
+	index int
+}
+
+func NewGronPrinter(out io.Writer) Printer {
+	return &gron{out: out}
+}
+
+func (this *gron) Print(token lexing.Token) {
+	switch token.Type {
+	case lexing.TokenWhitespace, lexing.TokenColon:
+	case lexing.TokenComma:
+		this.awaitingKey = len(this.frames) > 0 && !this.frames[len(this.frames)-1].array
+	case lexing.TokenString:
+		if this.awaitingKey {
+			this.key = gronAccessor(token.Value)
+			this.awaitingKey = false
+			return
+		}
+		this.assign(token.Value)
+	case lexing.TokenNumber, lexing.TokenTrue, lexing.TokenFalse, lexing.TokenNull:
+		this.assign(token.Value)
+	case lexing.TokenObjectStart, lexing.TokenArrayStart:
+		array := token.Type == lexing.TokenArrayStart
+		path := this.assign(emptyContainers[array])
+		this.frames = append(this.frames, gronFrame{path: path, array: array})
+		this.awaitingKey = !array
+	case lexing.TokenObjectStop, lexing.TokenArrayStop:
+		if len(this.frames) > 0 {
+			this.frames = this.frames[:len(this.frames)-1]
+		}
+	case lexing.TokenIllegal:
+		this.lineBreak()
+		_, _ = this.out.Write(token.Value)
+	}
+}
+
+// assign writes the statement that assigns value to the current path, and returns that path.
+func (this *gron) assign(value []byte) (path []byte) {
+	path = []byte("json")
+	if len(this.frames) > 0 {
+		top := &this.frames[len(this.frames)-1]
+		path = append([]byte(nil), top.path...)
+		if top.array {
+			path = append(append(append(path, '['), strconv.Itoa(top.index)...), ']')
+			top.index++
+		} else {
+			path = append(path, this.key...)
+		}
+	}
+	this.lineBreak()
+	_, _ = this.out.Write(path)
+	_, _ = io.WriteString(this.out, " = ")
+	_, _ = this.out.Write(value)
+	_, _ = io.WriteString(this.out, ";")
+	return path
+}
+func (this *gron) lineBreak() {
+	if this.started {
+		_, _ = io.WriteString(this.out, "\n")
+	}
+	this.started = true
+}
+
+var emptyContainers = map[bool][]byte{false: []byte("{}"), true: []byte("[]")}
+
+// gronAccessor returns `.key` for keys that are identifiers and `["key"]` for any other key.
+func gronAccessor(key []byte) []byte {
+	if name := lexing.Unquote(key); gronIdentifier(name) {
+		return append([]byte("."), name...)
+	}
+	return append(append([]byte("["), key...), ']')
+}
+
+// gronIdentifier reports whether key may be written as `.key` in gron statements.
+func gronIdentifier(key string) bool {
+	for x, c := range key {
+		if !unicode.IsLetter(c) && c != '_' && c != '$' && (x == 0 || !unicode.IsDigit(c)) {
+			return false
+		}
+	}
+	return key != ""
+}
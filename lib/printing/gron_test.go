@@ -0,0 +1,45 @@
+package printing
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/mdwhatcott/coding-challenges.fyi-json/lib/lexing"
+	"github.com/mdwhatcott/testing/should"
+)
+
+func TestGronPrinter(t *testing.T) {
+	testGron(t, `"hi"`, `json = "hi";`)
+	testGron(t, `[]`, `json = [];`)
+	testGron(t, `{"users": [{"name": "bob", "admin": false}, null], "a.b": {}, "$id": 1e3, "ünï": "\"x\"", "2x": true}`, ""+
+		"json = {};\n"+
+		"json.users = [];\n"+
+		"json.users[0] = {};\n"+
+		"json.users[0].name = \"bob\";\n"+
+		"json.users[0].admin = false;\n"+
+		"json.users[1] = null;\n"+
+		"json[\"a.b\"] = {};\n"+
+		"json.$id = 1e3;\n"+
+		"json.ünï = \"\\\"x\\\"\";\n"+
+		"json[\"2x\"] = true;")
+	testGron(t, `[[1], [2, [3]]]`, ""+
+		"json = [];\n"+
+		"json[0] = [];\n"+
+		"json[0][0] = 1;\n"+
+		"json[1] = [];\n"+
+		"json[1][0] = 2;\n"+
+		"json[1][1] = [];\n"+
+		"json[1][1][0] = 3;")
+	testGron(t, `{"a": tru`, "json = {};\ntru")
+}
+func testGron(t *testing.T, input, expected string) {
+	t.Run(input, func(t *testing.T) {
+		out := &bytes.Buffer{}
+		printer := NewGronPrinter(out)
+		for token := range lexing.Lex(strings.NewReader(input)) {
+			printer.Print(token)
+		}
+		should.So(t, out.String(), should.Equal, expected)
+	})
+}
@@ -0,0 +1,208 @@
+package printing
+
+import (
+	"fmt"
+	"html"
+	"io"
+
+	"github.com/mdwhatcott/coding-challenges.fyi-json/lib/lexing"
+	"github.com/mdwhatcott/coding-challenges.fyi-json/lib/pointer"
+)
+
+// htmlPrinter renders the token stream as syntax-highlighted HTML: each token
+// in a <span> with a "json-*" class, each non-empty array and object in a
+// collapsible <details> element, and each element and member in a <div>
+// whose id is the anchor prefix followed by its JSON Pointer (percent-encoded
+// as a URI fragment, RFC 6901 §6). The wrapper <div> (and the page) is closed
+// by Close, or when the input is found illegal, so that illegal trailing
+// input is still written inside it.
+type htmlPrinter struct {
+	out        io.Writer
+	standalone bool
+	title      string
+	prefix     string
+
+	tracker     pointer.Tracker
+	frames      []htmlFrame
+	pending     *lexing.Token // a container start, until we know whether it is empty
+	pendingPath string
+	started     bool
+	closed      bool
+}
+
+type htmlFrame struct {
+	pointer string
+	array   bool
+	member  bool // whether a member <div> is open
+}
+
+func NewHTMLPrinter(out io.Writer, options ...HTMLOption) *htmlPrinter {
+	this := &htmlPrinter{out: out, prefix: "json", title: "JSON"}
+	for _, option := range options {
+		option(this)
+	}
+	return this
+}
+
+type HTMLOption func(*htmlPrinter)
+
+// HTMLStandalone wraps the output in a complete page with the given title and HTMLStyle embedded.
+func HTMLStandalone(title string) HTMLOption {
+	return func(this *htmlPrinter) { this.standalone, this.title = true, title }
+}
+
+// HTMLAnchorPrefix sets the prefix of each id (default "json"), so that several
+// documents can share a page. The root value's id is the prefix itself.
+func HTMLAnchorPrefix(prefix string) HTMLOption {
+	return func(this *htmlPrinter) { this.prefix = prefix }
+}
+
+func (this *htmlPrinter) Print(token lexing.Token) {
+	if token.Type == lexing.TokenWhitespace {
+		return
+	}
+	this.start()
+	if this.pending != nil {
+		opener := *this.pending
+		this.pending = nil
+		if closes(opener, token) {
+			this.tracker.Step(token)
+			this.span("json-punctuation", string(opener.Value)+string(token.Value))
+			return
+		}
+		this.open(opener)
+	}
+	if token.Type == lexing.TokenIllegal {
+		this.span("json-illegal", string(token.Value))
+		this.finish()
+		_ = this.Close()
+		return
+	}
+	step := this.tracker.Step(token)
+	switch token.Type {
+	case lexing.TokenObjectStart, lexing.TokenArrayStart:
+		this.pendingPath = step.Pointer.Fragment()
+		this.beginValue(this.pendingPath)
+		this.pending = &token
+	case lexing.TokenObjectStop, lexing.TokenArrayStop:
+		this.closeMember()
+		this.span("json-punctuation", string(token.Value))
+		this.write("</details>")
+		this.frames = this.frames[:len(this.frames)-1]
+	case lexing.TokenComma:
+		this.span("json-punctuation", string(token.Value))
+		this.closeMember()
+	case lexing.TokenColon:
+		this.span("json-punctuation", string(token.Value))
+		this.write(" ")
+	case lexing.TokenString:
+		if !step.Begin { // a key
+			top := &this.frames[len(this.frames)-1]
+			member := pointer.Pointer{lexing.Unquote(token.Value)}
+			this.openMember(top, top.pointer+member.Fragment())
+			this.span("json-key", string(token.Value))
+			return
+		}
+		this.scalar(step, "json-string", token)
+	case lexing.TokenNumber:
+		this.scalar(step, "json-number", token)
+	case lexing.TokenTrue, lexing.TokenFalse:
+		this.scalar(step, "json-boolean", token)
+	case lexing.TokenNull:
+		this.scalar(step, "json-null", token)
+	}
+}
+func (this *htmlPrinter) scalar(step pointer.Step, class string, token lexing.Token) {
+	this.beginValue(step.Pointer.Fragment())
+	this.span(class, string(token.Value))
+}
+
+// beginValue opens the <div> of an array element (members are opened at their key).
+func (this *htmlPrinter) beginValue(path string) {
+	if len(this.frames) > 0 && this.frames[len(this.frames)-1].array {
+		this.openMember(&this.frames[len(this.frames)-1], path)
+	}
+}
+func (this *htmlPrinter) open(opener lexing.Token) {
+	kind := "json-object"
+	if opener.Type == lexing.TokenArrayStart {
+		kind = "json-array"
+	}
+	this.write(fmt.Sprintf(`<details open class="%s"><summary>`, kind))
+	this.span("json-punctuation", string(opener.Value))
+	this.write("</summary>\n")
+	this.frames = append(this.frames, htmlFrame{pointer: this.pendingPath, array: opener.Type == lexing.TokenArrayStart})
+}
+func (this *htmlPrinter) openMember(frame *htmlFrame, path string) {
+	this.write(fmt.Sprintf(`<div id="%s">`, html.EscapeString(this.prefix+path)))
+	frame.member = true
+}
+func (this *htmlPrinter) closeMember() {
+	if top := &this.frames[len(this.frames)-1]; top.member {
+		this.write("</div>\n")
+		top.member = false
+	}
+}
+func (this *htmlPrinter) span(class, text string) {
+	this.write(fmt.Sprintf(`<span class="%s">%s</span>`, class, html.EscapeString(text)))
+}
+func (this *htmlPrinter) start() {
+	if this.started {
+		return
+	}
+	this.started = true
+	if this.standalone {
+		this.write("<!DOCTYPE html>\n<html>\n<head>\n<meta charset=\"utf-8\">\n")
+		this.write("<title>" + html.EscapeString(this.title) + "</title>\n")
+		this.write("<style>\n" + HTMLStyle + "</style>\n</head>\n<body>\n")
+	}
+	this.write(fmt.Sprintf(`<div class="json" id="%s">`, html.EscapeString(this.prefix)))
+}
+
+// finish closes the containers that are still open when the input is found illegal.
+func (this *htmlPrinter) finish() {
+	for len(this.frames) > 0 {
+		this.closeMember()
+		this.write("</details>")
+		this.frames = this.frames[:len(this.frames)-1]
+	}
+}
+
+// Close closes the wrapper <div> (and the page) after the last token. Like the
+// other printers, it leaves the final line unterminated.
+func (this *htmlPrinter) Close() error {
+	if !this.started || this.closed {
+		return nil
+	}
+	this.closed = true
+	this.write("</div>")
+	if this.standalone {
+		this.write("\n</body>\n</html>")
+	}
+	return nil
+}
+func (this *htmlPrinter) write(text string) {
+	_, _ = io.WriteString(this.out, text)
+}
+func closes(opener, token lexing.Token) bool {
+	return (opener.Type == lexing.TokenObjectStart && token.Type == lexing.TokenObjectStop) ||
+		(opener.Type == lexing.TokenArrayStart && token.Type == lexing.TokenArrayStop)
+}
+
+// HTMLStyle is the CSS embedded in standalone pages, for use in pages of your own.
+const HTMLStyle = `.json { font-family: ui-monospace, monospace; white-space: pre-wrap; }
+.json details { display: inline-block; vertical-align: top; }
+.json details > div { margin-left: 2ch; }
+.json summary { display: inline; cursor: pointer; list-style: none; }
+.json summary::-webkit-details-marker { display: none; }
+.json details.json-object:not([open]) > summary::after { content: " … }"; color: #93a1a1; }
+.json details.json-array:not([open]) > summary::after { content: " … ]"; color: #93a1a1; }
+.json div:target { background: #fdf6e3; }
+.json-key { color: #268bd2; }
+.json-string { color: #2aa198; }
+.json-number { color: #b58900; }
+.json-boolean { color: #859900; }
+.json-null { color: #93a1a1; }
+.json-punctuation { color: #586e75; }
+.json-illegal { color: #dc322f; text-decoration: wavy underline; }
+`
@@ -0,0 +1,71 @@
+package printing
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/mdwhatcott/coding-challenges.fyi-json/lib/lexing"
+	"github.com/mdwhatcott/testing/should"
+)
+
+func TestHTMLPrinter(t *testing.T) {
+	testHTML(t, `{"a/b": [1, "<&>"], "c": {}, "d": [null, {"e": true}]}`, ""+
+		`<div class="json" id="json"><details open class="json-object"><summary><span class="json-punctuation">{</span></summary>`+"\n"+
+		`<div id="json/a~1b"><span class="json-key">&#34;a/b&#34;</span><span class="json-punctuation">:</span> `+
+		`<details open class="json-array"><summary><span class="json-punctuation">[</span></summary>`+"\n"+
+		`<div id="json/a~1b/0"><span class="json-number">1</span><span class="json-punctuation">,</span></div>`+"\n"+
+		`<div id="json/a~1b/1"><span class="json-string">&#34;&lt;&amp;&gt;&#34;</span></div>`+"\n"+
+		`<span class="json-punctuation">]</span></details><span class="json-punctuation">,</span></div>`+"\n"+
+		`<div id="json/c"><span class="json-key">&#34;c&#34;</span><span class="json-punctuation">:</span> <span class="json-punctuation">{}</span><span class="json-punctuation">,</span></div>`+"\n"+
+		`<div id="json/d"><span class="json-key">&#34;d&#34;</span><span class="json-punctuation">:</span> `+
+		`<details open class="json-array"><summary><span class="json-punctuation">[</span></summary>`+"\n"+
+		`<div id="json/d/0"><span class="json-null">null</span><span class="json-punctuation">,</span></div>`+"\n"+
+		`<div id="json/d/1"><details open class="json-object"><summary><span class="json-punctuation">{</span></summary>`+"\n"+
+		`<div id="json/d/1/e"><span class="json-key">&#34;e&#34;</span><span class="json-punctuation">:</span> <span class="json-boolean">true</span></div>`+"\n"+
+		`<span class="json-punctuation">}</span></details></div>`+"\n"+
+		`<span class="json-punctuation">]</span></details></div>`+"\n"+
+		`<span class="json-punctuation">}</span></details></div>`)
+	testHTML(t, ` 42 `, `<div class="json" id="json"><span class="json-number">42</span></div>`)
+	testHTML(t, `[]`, `<div class="json" id="json"><span class="json-punctuation">[]</span></div>`)
+	testHTML(t, `[1, tru`, ""+
+		`<div class="json" id="json"><details open class="json-array"><summary><span class="json-punctuation">[</span></summary>`+"\n"+
+		`<div id="json/0"><span class="json-number">1</span><span class="json-punctuation">,</span></div>`+"\n"+
+		`<span class="json-illegal">tru</span></details></div>`)
+	testHTML(t, `{"a b": {"%/é": 1}}`, ""+
+		`<div class="json" id="json"><details open class="json-object"><summary><span class="json-punctuation">{</span></summary>`+"\n"+
+		`<div id="json/a%20b"><span class="json-key">&#34;a b&#34;</span><span class="json-punctuation">:</span> `+
+		`<details open class="json-object"><summary><span class="json-punctuation">{</span></summary>`+"\n"+
+		`<div id="json/a%20b/%25~1%C3%A9"><span class="json-key">&#34;%/é&#34;</span><span class="json-punctuation">:</span> <span class="json-number">1</span></div>`+"\n"+
+		`<span class="json-punctuation">}</span></details></div>`+"\n"+
+		`<span class="json-punctuation">}</span></details></div>`)
+	testHTML(t, `1 2`, `<div class="json" id="json"><span class="json-number">1</span><span class="json-illegal">2</span></div>`)
+	testHTML(t, `{"a":1}{"b":2}`, ""+
+		`<div class="json" id="json"><details open class="json-object"><summary><span class="json-punctuation">{</span></summary>`+"\n"+
+		`<div id="json/a"><span class="json-key">&#34;a&#34;</span><span class="json-punctuation">:</span> <span class="json-number">1</span></div>`+"\n"+
+		`<span class="json-punctuation">}</span></details><span class="json-illegal">{&#34;b&#34;:2}</span></div>`)
+}
+func testHTML(t *testing.T, input, expected string, options ...HTMLOption) {
+	t.Run(input, func(t *testing.T) {
+		out := &bytes.Buffer{}
+		printer := NewHTMLPrinter(out, options...)
+		for token := range lexing.Lex(strings.NewReader(input)) {
+			printer.Print(token)
+		}
+		_ = printer.Close()
+		should.So(t, out.String(), should.Equal, expected)
+	})
+}
+func TestHTMLPrinter_Standalone(t *testing.T) {
+	out := &bytes.Buffer{}
+	printer := NewHTMLPrinter(out, HTMLStandalone("Example <1>"), HTMLAnchorPrefix("doc-"))
+	for token := range lexing.Lex(strings.NewReader(`{"a": 1}`)) {
+		printer.Print(token)
+	}
+	_ = printer.Close()
+	page := out.String()
+	should.So(t, strings.HasPrefix(page, "<!DOCTYPE html>\n<html>\n<head>\n<meta charset=\"utf-8\">\n<title>Example &lt;1&gt;</title>\n<style>\n"+HTMLStyle), should.BeTrue)
+	should.So(t, strings.Contains(page, `<div class="json" id="doc-">`), should.BeTrue)
+	should.So(t, strings.Contains(page, `<div id="doc-/a">`), should.BeTrue)
+	should.So(t, strings.HasSuffix(page, "</details></div>\n</body>\n</html>"), should.BeTrue)
+}
@@ -8,16 +8,22 @@ import (
 )
 
 type indent struct {
-	out   io.Writer
+	layout
 	state []lexing.TokenType
 	items []int
 
 	awaitingArrayValue  bool
 	awaitingObjectValue bool
+
+	pending   []lexing.Token // an object being buffered to measure its keys
+	depth     int
+	widths    []int // the aligned key widths of the objects yet to be printed from pending, in order
+	keyWidths []int // the aligned key width of each open object
+	keyWidth  int   // the width of the last key written
 }
 
-func NewIndentingPrinter(out io.Writer) Printer {
-	return &indent{out: out}
+func NewIndentingPrinter(out io.Writer, options ...IndentOption) Printer {
+	return &indent{layout: newLayout(out, options)}
 }
 
 func (this *indent) nested() bool {
@@ -29,15 +35,28 @@ func (this *indent) nested() bool {
 }
 
 func (this *indent) Print(token lexing.Token) {
+	if this.align > 0 && this.buffer(token) {
+		return
+	}
+	this.print(token)
+}
+func (this *indent) print(token lexing.Token) {
 	switch token.Type {
 	case lexing.TokenArrayStart, lexing.TokenObjectStart:
 		if this.nested() {
 			this.indent()
 		}
+		if len(this.items) > 0 {
+			this.items[len(this.items)-1]++
+		}
 		this.write(token.Value)
 		this.state = append(this.state, token.Type)
 		this.items = append(this.items, 0)
 		this.awaitingArrayValue = true
+		if token.Type == lexing.TokenObjectStart && this.align > 0 && len(this.widths) > 0 {
+			this.keyWidths = append(this.keyWidths, this.widths[0])
+			this.widths = this.widths[1:]
+		}
 	case lexing.TokenArrayStop, lexing.TokenObjectStop:
 		this.state = this.state[:len(this.state)-1]
 		if this.items[len(this.items)-1] > 0 {
@@ -45,35 +64,97 @@ func (this *indent) Print(token lexing.Token) {
 		}
 		this.items = this.items[:len(this.items)-1]
 		this.write(token.Value)
+		if token.Type == lexing.TokenObjectStop && this.align > 0 {
+			this.keyWidths = this.keyWidths[:len(this.keyWidths)-1]
+		}
 	case lexing.TokenNull, lexing.TokenTrue, lexing.TokenFalse, lexing.TokenString, lexing.TokenNumber:
 		if len(this.state) > 0 {
 			this.items[len(this.items)-1]++
 			if !this.awaitingObjectValue || this.awaitingArrayValue {
 				this.indent()
+				this.keyWidth = visibleWidth(token.Value)
 			}
-			this.write(token.Value)
 		}
+		this.write(token.Value)
 		this.awaitingObjectValue = false
 		this.awaitingArrayValue = false
 	case lexing.TokenComma, lexing.TokenIllegal:
 		this.write(token.Value)
 	case lexing.TokenColon:
 		this.awaitingObjectValue = true
+		if this.alignColons {
+			this.pad()
+		}
 		this.write(token.Value)
 		this.write(space)
+		if !this.alignColons {
+			this.pad()
+		}
 	}
 }
 
-func (this *indent) write(data []byte) {
-	_, _ = this.out.Write(data)
+// buffer collects the tokens of each top-level object, when aligning, until
+// it is complete (or the input ends) and can be printed with the keys of it
+// and every object within it measured (in a single pass).
+func (this *indent) buffer(token lexing.Token) bool {
+	if len(this.pending) == 0 && token.Type != lexing.TokenObjectStart {
+		return false
+	}
+	this.pending = append(this.pending, token)
+	switch token.Type {
+	case lexing.TokenObjectStart, lexing.TokenArrayStart:
+		this.depth++
+	case lexing.TokenObjectStop, lexing.TokenArrayStop:
+		this.depth--
+	}
+	if this.depth == 0 || token.Type == lexing.TokenIllegal {
+		tokens := this.pending
+		this.pending, this.depth = nil, 0
+		this.widths = alignedKeyWidths(tokens, this.align)
+		for _, token := range tokens {
+			this.print(token)
+		}
+	}
+	return true
 }
+
+// pad fills the space between the last key written and the alignment column.
+func (this *indent) pad() {
+	if len(this.keyWidths) == 0 {
+		return
+	}
+	if padding := this.keyWidths[len(this.keyWidths)-1] - this.keyWidth; padding > 0 {
+		this.write(bytes.Repeat(space, padding))
+	}
+}
+
+// alignedKeyWidths measures the widest key (no wider than limit) of each
+// object among the tokens, listing the widths in the order the objects begin.
+func alignedKeyWidths(tokens []lexing.Token, limit int) (widths []int) {
+	var open []int // the index in widths of each open object (-1 for arrays)
+	var key lexing.Token
+	for _, token := range tokens {
+		switch token.Type {
+		case lexing.TokenObjectStart:
+			open = append(open, len(widths))
+			widths = append(widths, 0)
+		case lexing.TokenArrayStart:
+			open = append(open, -1)
+		case lexing.TokenObjectStop, lexing.TokenArrayStop:
+			open = open[:len(open)-1]
+		case lexing.TokenString:
+			key = token
+		case lexing.TokenColon:
+			if keyWidth := visibleWidth(key.Value); len(open) > 0 && open[len(open)-1] >= 0 && keyWidth <= limit {
+				widths[open[len(open)-1]] = max(widths[open[len(open)-1]], keyWidth)
+			}
+		}
+	}
+	return widths
+}
+
 func (this *indent) indent() {
-	this.write(newline)
-	this.write(bytes.Repeat(indentation, len(this.state)))
+	this.lineBreak(len(this.state))
 }
 
-var (
-	space       = []byte(" ")
-	newline     = []byte("\n")
-	indentation = []byte("  ")
-)
+var space = []byte(" ")
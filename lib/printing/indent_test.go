@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/mdwhatcott/coding-challenges.fyi-json/lib/lexing"
@@ -27,3 +28,93 @@ func TestIndentingPrinter(t *testing.T) {
 	}
 	should.So(t, out.String(), should.Equal, string(expected))
 }
+
+func TestIndentingPrinterTopLevelScalar(t *testing.T) {
+	out := &bytes.Buffer{}
+	printer := NewIndentingPrinter(out)
+	for token := range lexing.Lex(strings.NewReader(` "a" `)) {
+		printer.Print(token)
+	}
+	should.So(t, out.String(), should.Equal, `"a"`)
+}
+
+func TestIndentingPrinterNestedContainers(t *testing.T) {
+	out := &bytes.Buffer{}
+	printer := NewIndentingPrinter(out)
+	for token := range lexing.Lex(strings.NewReader(`[{"a":[]},{}]`)) {
+		printer.Print(token)
+	}
+	should.So(t, out.String(), should.Equal, "[\n  {\n    \"a\": []\n  },\n  {}\n]")
+}
+
+func TestIndentingPrinterOptions(t *testing.T) {
+	testIndentOptions(t, "{\n    \"a\": [\n        1\n    ]\n}", IndentWidth(4))
+	testIndentOptions(t, "{\n\t\"a\": [\n\t\t1\n\t]\n}", IndentTabs())
+	testIndentOptions(t, "// {\n//   \"a\": [\n//     1\n//   ]\n// }", IndentPrefix("// "))
+	testIndentOptions(t, "{\r\n\"a\": [\r\n1\r\n]\r\n}", IndentCRLF(), IndentWidth(0))
+}
+func testIndentOptions(t *testing.T, expected string, options ...IndentOption) {
+	out := &bytes.Buffer{}
+	printer := NewIndentingPrinter(out, options...)
+	for token := range lexing.Lex(strings.NewReader(`{"a":[1]}`)) {
+		printer.Print(token)
+	}
+	should.So(t, out.String(), should.Equal, expected)
+}
+
+func TestIndentingPrinterAlignment(t *testing.T) {
+	input := `{"id": 1, "name": {"first": "a", "l": "b"}, "a_very_long_key": [{"x": 1, "yy": 2}], "b": null}`
+	testIndentAlignment(t, input, IndentAlignValues(8), ""+
+		"{\n"+
+		"  \"id\":   1,\n"+
+		"  \"name\": {\n"+
+		"    \"first\": \"a\",\n"+
+		"    \"l\":     \"b\"\n"+
+		"  },\n"+
+		"  \"a_very_long_key\": [\n"+
+		"    {\n"+
+		"      \"x\":  1,\n"+
+		"      \"yy\": 2\n"+
+		"    }\n"+
+		"  ],\n"+
+		"  \"b\":    null\n"+
+		"}")
+	testIndentAlignment(t, `[{"a": 1, "bbb": {}}, {"cc": 2}]`, IndentAlignColons(10), ""+
+		"[\n"+
+		"  {\n"+
+		"    \"a\"  : 1,\n"+
+		"    \"bbb\": {}\n"+
+		"  },\n"+
+		"  {\n"+
+		"    \"cc\": 2\n"+
+		"  }\n"+
+		"]")
+	testIndentAlignment(t, `{"a": 1, "bb": [2`, IndentAlignValues(10), "{\n  \"a\":  1,\n  \"bb\": [\n    2")
+}
+func testIndentAlignment(t *testing.T, input string, option IndentOption, expected string) {
+	out := &bytes.Buffer{}
+	printer := NewInlineColorPrinter(NewIndentingPrinter(out, option))
+	for token := range lexing.Lex(strings.NewReader(input)) {
+		printer.Print(token)
+	}
+	should.So(t, stripColors(out.String()), should.Equal, expected)
+}
+func TestAlignedKeyWidths(t *testing.T) {
+	var tokens []lexing.Token
+	for token := range lexing.Lex(strings.NewReader(`{"a": {"bbb": [{"cc": 1}], "d": 2}, "eeeeeeeeeeee": 3, "ff": {}}`)) {
+		tokens = append(tokens, token)
+	}
+	should.So(t, alignedKeyWidths(tokens, 10), should.Equal, []int{4, 5, 4, 0})
+}
+func TestIndentingPrinterAlignmentDeeplyNested(t *testing.T) {
+	const depth = 300
+	input := strings.Repeat(`{"a":`, depth) + "1" + strings.Repeat("}", depth)
+	aligned, plain := &bytes.Buffer{}, &bytes.Buffer{}
+	alignedPrinter := NewIndentingPrinter(aligned, IndentWidth(0), IndentAlignValues(8))
+	plainPrinter := NewIndentingPrinter(plain, IndentWidth(0))
+	for token := range lexing.Lex(strings.NewReader(input)) {
+		alignedPrinter.Print(token)
+		plainPrinter.Print(token)
+	}
+	should.So(t, aligned.String(), should.Equal, plain.String()) // (every key is as wide as the widest)
+}
@@ -0,0 +1,93 @@
+package printing
+
+import (
+	"bytes"
+	"io"
+	"unicode/utf8"
+)
+
+// layout holds the whitespace settings shared by the printers that break lines,
+// and writes their output while keeping track of the current column.
+type layout struct {
+	out         io.Writer
+	indentation []byte
+	newline     []byte
+	prefix      []byte
+	started     bool
+	column      int
+	align       int
+	alignColons bool
+}
+
+func newLayout(out io.Writer, options []IndentOption) layout {
+	this := layout{out: out, indentation: []byte("  "), newline: []byte("\n")}
+	for _, option := range options {
+		option(&this)
+	}
+	return this
+}
+
+type IndentOption func(*layout)
+
+// IndentWidth sets the number of spaces per level of nesting (default 2).
+func IndentWidth(width int) IndentOption {
+	return func(this *layout) { this.indentation = bytes.Repeat([]byte(" "), width) }
+}
+
+// IndentTabs indents with one tab per level of nesting.
+func IndentTabs() IndentOption {
+	return func(this *layout) { this.indentation = []byte("\t") }
+}
+
+// IndentPrefix begins every line of output with prefix.
+func IndentPrefix(prefix string) IndentOption {
+	return func(this *layout) { this.prefix = []byte(prefix) }
+}
+
+// IndentCRLF ends lines with "\r\n" rather than "\n".
+func IndentCRLF() IndentOption {
+	return func(this *layout) { this.newline = []byte("\r\n") }
+}
+
+// IndentAlignValues pads the keys of each object so its values line up in a column,
+// except for keys wider than limit, which are left unpadded (indenting printer only).
+func IndentAlignValues(limit int) IndentOption {
+	return func(this *layout) { this.align, this.alignColons = limit, false }
+}
+
+// IndentAlignColons is like IndentAlignValues, but pads before the colons, lining them up instead.
+func IndentAlignColons(limit int) IndentOption {
+	return func(this *layout) { this.align, this.alignColons = limit, true }
+}
+
+func (this *layout) write(data []byte) {
+	if !this.started {
+		this.started = true
+		this.write(this.prefix)
+	}
+	_, _ = this.out.Write(data)
+	this.column += visibleWidth(data)
+}
+func (this *layout) lineBreak(depth int) {
+	this.write(this.newline)
+	this.column = 0
+	this.write(this.prefix)
+	this.write(bytes.Repeat(this.indentation, depth))
+}
+
+// visibleWidth counts the runes of data, skipping ANSI escape sequences (as written by the color printer).
+func visibleWidth(data []byte) (width int) {
+	for len(data) > 0 {
+		if data[0] == '\033' && len(data) > 1 && data[1] == '[' {
+			end := bytes.IndexFunc(data[2:], func(r rune) bool { return r >= '@' && r <= '~' })
+			if end >= 0 {
+				data = data[end+3:]
+				continue
+			}
+		}
+		_, size := utf8.DecodeRune(data)
+		data = data[size:]
+		width++
+	}
+	return width
+}
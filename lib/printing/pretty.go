@@ -0,0 +1,140 @@
+package printing
+
+import (
+	"io"
+
+	"github.com/mdwhatcott/coding-challenges.fyi-json/lib/lexing"
+)
+
+// pretty lays out each array and object on a single line when it fits within
+// the configured width, and otherwise breaks it like the indenting printer.
+// Tokens are buffered from the start of a container until it either closes
+// (and fits) or grows beyond the remaining width (and must break), so the
+// lookahead never exceeds one line.
+type pretty struct {
+	layout
+	width   int
+	frames  []*prettyFrame
+	pending []lexing.Token
+	depth   int  // nesting of the pending tokens
+	length  int  // visible width of the pending tokens, laid out on one line
+	closed  bool // whether the pending container is complete (but might yet be followed by a comma)
+}
+
+type prettyFrame struct {
+	items      int
+	afterColon bool
+}
+
+func NewPrettyPrinter(out io.Writer, width int, options ...IndentOption) Printer {
+	return &pretty{layout: newLayout(out, options), width: width}
+}
+
+func (this *pretty) Print(token lexing.Token) {
+	if token.Type == lexing.TokenWhitespace {
+		return
+	}
+	if len(this.pending) > 0 {
+		this.lookahead(token)
+		return
+	}
+	switch token.Type {
+	case lexing.TokenArrayStart, lexing.TokenObjectStart:
+		this.beginItem()
+		this.pending = append(this.pending, token)
+		this.depth = 1
+		this.length = visibleWidth(token.Value)
+	case lexing.TokenArrayStop, lexing.TokenObjectStop:
+		frame := this.frames[len(this.frames)-1]
+		this.frames = this.frames[:len(this.frames)-1]
+		if frame.items > 0 {
+			this.lineBreak(len(this.frames))
+		}
+		this.write(token.Value)
+	case lexing.TokenColon:
+		this.write(token.Value)
+		this.write(space)
+		this.frames[len(this.frames)-1].afterColon = true
+	case lexing.TokenComma, lexing.TokenIllegal:
+		this.write(token.Value)
+	default:
+		this.beginItem()
+		this.write(token.Value)
+	}
+}
+
+// beginItem starts a new line for each element of an array or member of an
+// object that has been broken across lines.
+func (this *pretty) beginItem() {
+	if len(this.frames) == 0 {
+		return
+	}
+	frame := this.frames[len(this.frames)-1]
+	if frame.afterColon {
+		frame.afterColon = false
+		return
+	}
+	frame.items++
+	this.lineBreak(len(this.frames))
+}
+func (this *pretty) lookahead(token lexing.Token) {
+	if this.closed {
+		this.closed = false
+		if token.Type == lexing.TokenComma && this.column+this.length+visibleWidth(token.Value) > this.width {
+			this.breakPending()
+		} else {
+			this.flush()
+		}
+		this.Print(token)
+		return
+	}
+	this.pending = append(this.pending, token)
+	switch token.Type {
+	case lexing.TokenArrayStart, lexing.TokenObjectStart:
+		this.depth++
+	case lexing.TokenArrayStop, lexing.TokenObjectStop:
+		this.depth--
+	case lexing.TokenIllegal:
+		this.flush()
+		return
+	}
+	this.length += flatWidth(token)
+	fits := this.column+this.length <= this.width
+	if this.depth == 0 && fits && len(this.frames) == 0 {
+		this.flush()
+	} else if this.depth == 0 && fits {
+		this.closed = true // the line must have room for a comma, too
+	} else if !fits {
+		this.breakPending()
+	}
+}
+
+// flush writes the pending tokens on one line.
+func (this *pretty) flush() {
+	for _, token := range this.pending {
+		this.write(token.Value)
+		if token.Type == lexing.TokenComma || token.Type == lexing.TokenColon {
+			this.write(space)
+		}
+	}
+	this.pending = nil
+}
+
+// breakPending opens the outermost pending container on its own line and
+// reconsiders its contents, each of which may yet fit on a line of its own.
+func (this *pretty) breakPending() {
+	tokens := this.pending
+	this.pending = nil
+	this.write(tokens[0].Value)
+	this.frames = append(this.frames, &prettyFrame{})
+	for _, token := range tokens[1:] {
+		this.Print(token)
+	}
+}
+func flatWidth(token lexing.Token) int {
+	width := visibleWidth(token.Value)
+	if token.Type == lexing.TokenComma || token.Type == lexing.TokenColon {
+		width++
+	}
+	return width
+}
@@ -0,0 +1,88 @@
+package printing
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/mdwhatcott/coding-challenges.fyi-json/lib/lexing"
+	"github.com/mdwhatcott/testing/should"
+)
+
+func TestPrettyPrinter(t *testing.T) {
+	testPretty(t, 80, `[1,2,3]`, `[1, 2, 3]`)
+	testPretty(t, 80, `{"a":{},"b":[]}`, `{"a": {}, "b": []}`)
+	testPretty(t, 80, ` "top" `, `"top"`)
+	testPretty(t, 21, `{"name":"widget","sizes":[1,2,3],"tags":{"a":true,"b":false}}`, ""+
+		"{\n"+
+		"  \"name\": \"widget\",\n"+
+		"  \"sizes\": [1, 2, 3],\n"+
+		"  \"tags\": {\n"+
+		"    \"a\": true,\n"+
+		"    \"b\": false\n"+
+		"  }\n"+
+		"}")
+	testPretty(t, 10, `[[1,2],[3,4,5,6,7],[]]`, ""+
+		"[\n"+
+		"  [1, 2],\n"+
+		"  [\n"+
+		"    3,\n"+
+		"    4,\n"+
+		"    5,\n"+
+		"    6,\n"+
+		"    7\n"+
+		"  ],\n"+
+		"  []\n"+
+		"]")
+	testPretty(t, 8, `[[1,2],[3,4]]`, ""+ // "  [1, 2]," would be 9 wide
+		"[\n"+
+		"  [\n"+
+		"    1,\n"+
+		"    2\n"+
+		"  ],\n"+
+		"  [3, 4]\n"+
+		"]")
+	testPretty(t, 9, `[[1,2],[3,4]]`, "[\n  [1, 2],\n  [3, 4]\n]")
+	testPretty(t, 0, `{"a":[1]}`, "{\n  \"a\": [\n    1\n  ]\n}")
+	testPretty(t, 80, `[1, [2, `, `[1, [2, `)
+	testPretty(t, 80, `[1, 2} `, `[1, 2} `)
+}
+func testPretty(t *testing.T, width int, input, expected string) {
+	t.Run(input, func(t *testing.T) {
+		out := &bytes.Buffer{}
+		printer := NewPrettyPrinter(out, width)
+		for token := range lexing.Lex(strings.NewReader(input)) {
+			printer.Print(token)
+		}
+		should.So(t, out.String(), should.Equal, expected)
+	})
+}
+func TestPrettyPrinter_ColorsAndOptions(t *testing.T) {
+	out := &bytes.Buffer{}
+	printer := NewInlineColorPrinter(NewPrettyPrinter(out, 16, IndentTabs(), IndentPrefix("> ")))
+	for token := range lexing.Lex(strings.NewReader(`{"a":[1,2],"b":[1,2,3,4]}`)) {
+		printer.Print(token)
+	}
+	should.So(t, stripColors(out.String()), should.Equal, ""+
+		"> {\n"+
+		"> \t\"a\": [1, 2],\n"+
+		"> \t\"b\": [\n"+
+		"> \t\t1,\n"+
+		"> \t\t2,\n"+
+		"> \t\t3,\n"+
+		"> \t\t4\n"+
+		"> \t]\n"+
+		"> }")
+}
+func stripColors(text string) string {
+	for _, color := range [][]byte{Reset, Red, Green, Yellow, Blue, Purple, Cyan, Gray, White} {
+		text = strings.ReplaceAll(text, string(color), "")
+	}
+	return text
+}
+func TestVisibleWidth(t *testing.T) {
+	should.So(t, visibleWidth([]byte("abc")), should.Equal, 3)
+	should.So(t, visibleWidth([]byte("é€")), should.Equal, 2)
+	should.So(t, visibleWidth([]byte("\x1b[36m{\x1b[0m")), should.Equal, 1)
+	should.So(t, visibleWidth([]byte("\x1b[38;5;208mx\x1b[0m")), should.Equal, 1)
+}
@@ -0,0 +1,130 @@
+package printing
+
+import (
+	"cmp"
+	"slices"
+
+	"github.com/mdwhatcott/coding-challenges.fyi-json/lib/lexing"
+)
+
+// sorting reorders the members of each object (recursively, stably, by decoded
+// key) before passing the tokens on to the inner printer. Each object is
+// buffered until it is complete; whitespace within buffered objects is dropped.
+type sorting struct {
+	inner  Printer
+	frames []*sortingFrame
+}
+
+type sortingFrame struct {
+	object    bool
+	expectKey bool
+	member    sortingMember
+	members   []sortingMember
+}
+
+type sortingMember struct {
+	key    string
+	tokens []lexing.Token
+}
+
+func NewSortingPrinter(inner Printer) Printer {
+	return &sorting{inner: inner}
+}
+
+func (this *sorting) Print(token lexing.Token) {
+	switch token.Type {
+	case lexing.TokenWhitespace:
+		if !this.buffering() {
+			this.inner.Print(token)
+		}
+	case lexing.TokenObjectStart:
+		this.frames = append(this.frames, &sortingFrame{object: true, expectKey: true})
+	case lexing.TokenObjectStop:
+		frame := this.pop()
+		slices.SortStableFunc(frame.members, func(a, b sortingMember) int {
+			return cmp.Compare(a.key, b.key)
+		})
+		this.release(frame)
+		this.emit(lexing.Token{Type: lexing.TokenObjectStop, Value: token.Value})
+	case lexing.TokenArrayStart:
+		this.emit(token)
+		this.frames = append(this.frames, &sortingFrame{})
+	case lexing.TokenArrayStop:
+		this.pop()
+		this.emit(token)
+	case lexing.TokenComma:
+		if frame := this.top(); frame != nil && frame.object {
+			frame.finishMember()
+			frame.expectKey = true
+		} else {
+			this.emit(token)
+		}
+	case lexing.TokenString:
+		if frame := this.top(); frame != nil && frame.object && frame.expectKey {
+			frame.member.key = lexing.Unquote(token.Value)
+			frame.expectKey = false
+		}
+		this.emit(token)
+	case lexing.TokenIllegal:
+		// The document ends here, so release the open objects as they are.
+		for len(this.frames) > 0 {
+			if frame := this.pop(); frame.object {
+				this.release(frame)
+			}
+		}
+		this.inner.Print(token)
+	default:
+		this.emit(token)
+	}
+}
+
+func (this *sorting) buffering() bool {
+	for _, frame := range this.frames {
+		if frame.object {
+			return true
+		}
+	}
+	return false
+}
+func (this *sorting) top() *sortingFrame {
+	if len(this.frames) == 0 {
+		return nil
+	}
+	return this.frames[len(this.frames)-1]
+}
+func (this *sorting) pop() *sortingFrame {
+	frame := this.top()
+	this.frames = this.frames[:len(this.frames)-1]
+	frame.finishMember()
+	return frame
+}
+
+// release emits the opening brace and members of an object that has been popped.
+func (this *sorting) release(frame *sortingFrame) {
+	this.emit(lexing.Token{Type: lexing.TokenObjectStart, Value: []byte("{")})
+	for x, member := range frame.members {
+		if x > 0 {
+			this.emit(lexing.Token{Type: lexing.TokenComma, Value: []byte(",")})
+		}
+		for _, token := range member.tokens {
+			this.emit(token)
+		}
+	}
+}
+
+// emit buffers token in the member of the innermost object, if any.
+func (this *sorting) emit(token lexing.Token) {
+	for x := len(this.frames) - 1; x >= 0; x-- {
+		if frame := this.frames[x]; frame.object {
+			frame.member.tokens = append(frame.member.tokens, token)
+			return
+		}
+	}
+	this.inner.Print(token)
+}
+func (this *sortingFrame) finishMember() {
+	if len(this.member.tokens) > 0 {
+		this.members = append(this.members, this.member)
+	}
+	this.member = sortingMember{}
+}
@@ -0,0 +1,40 @@
+package printing
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/mdwhatcott/coding-challenges.fyi-json/lib/lexing"
+	"github.com/mdwhatcott/testing/should"
+)
+
+func TestSortingPrinter(t *testing.T) {
+	testSorting(t, `{"b": 1, "a": 2}`, `{"a":2,"b":1}`)
+	testSorting(t, `[{"z": {"y": [], "x": [{"d": 1, "c": 2}]}, "\u0061": null}, 3]`, `[{"\u0061":null,"z":{"x":[{"c":2,"d":1}],"y":[]}}, 3]`)
+	testSorting(t, `{"b": 1, "a": 2, "b": 0, "a": 1}`, `{"a":2,"a":1,"b":1,"b":0}`)
+	testSorting(t, `{"é": 1, "z": 2, "Z": 3}`, `{"Z":3,"z":2,"é":1}`)
+	testSorting(t, `{}`, `{}`)
+	testSorting(t, ` [ 1 , 2 ] `, ` [ 1 , 2 ] `)
+	testSorting(t, `{"b": [1, {"d": 1, "c": 2`, `{"b":[1,{"d":1,"c":2`)
+}
+func testSorting(t *testing.T, input, expected string) {
+	out := &bytes.Buffer{}
+	printer := NewSortingPrinter(NewVerbatimPrinter(out))
+	for token := range lexing.Lex(strings.NewReader(input)) {
+		printer.Print(token)
+	}
+	should.So(t, out.String(), should.Equal, expected)
+}
+func TestSortingPrinter_Colors(t *testing.T) {
+	out := &bytes.Buffer{}
+	printer := NewSortingPrinter(NewInlineColorPrinter(NewIndentingPrinter(out)))
+	for token := range lexing.Lex(strings.NewReader(`{"b":true,"a":null}`)) {
+		printer.Print(token)
+	}
+	should.So(t, out.String(), should.Equal, ""+
+		"\x1b[36m{\x1b[0m\n"+
+		"  \x1b[34m\"a\"\x1b[0m\x1b[36m:\x1b[0m \x1b[37mnull\x1b[0m\x1b[36m,\x1b[0m\n"+
+		"  \x1b[34m\"b\"\x1b[0m\x1b[36m:\x1b[0m \x1b[32mtrue\x1b[0m\n"+
+		"\x1b[36m}\x1b[0m")
+}
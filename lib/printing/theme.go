@@ -0,0 +1,116 @@
+package printing
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Theme holds the escape codes written before each kind of token (nil for no color).
+// Array and Object also color the commas (and colons) within them.
+type Theme struct {
+	Null    []byte
+	False   []byte
+	True    []byte
+	Number  []byte
+	String  []byte
+	Array   []byte
+	Object  []byte
+	Key     []byte
+	Illegal []byte
+}
+
+var (
+	DefaultTheme = Theme{
+		Null: Gray, False: Purple, True: Green, Number: Yellow, String: Blue,
+		Array: Cyan, Object: Cyan, Key: Blue, Illegal: Red,
+	}
+	DarkTheme = Theme{
+		Null: Color256(245), False: Color256(211), True: Color256(156), Number: Color256(222), String: Color256(117),
+		Array: White, Object: White, Key: Color256(111), Illegal: Color256(203),
+	}
+	LightTheme = Theme{
+		Null: Color256(242), False: Color256(90), True: Color256(28), Number: Color256(130), String: Color256(25),
+		Array: Color256(238), Object: Color256(238), Key: Color256(18), Illegal: Color256(160),
+	}
+	SolarizedTheme = Theme{
+		Null: TrueColor(0x58, 0x6e, 0x75), False: TrueColor(0xd3, 0x36, 0x82), True: TrueColor(0x85, 0x99, 0x00),
+		Number: TrueColor(0x2a, 0xa1, 0x98), String: TrueColor(0x26, 0x8b, 0xd2),
+		Array: TrueColor(0x93, 0xa1, 0xa1), Object: TrueColor(0x93, 0xa1, 0xa1), Key: TrueColor(0xb5, 0x89, 0x00),
+		Illegal: TrueColor(0xdc, 0x32, 0x2f),
+	}
+	MonochromeTheme = Theme{
+		Array: Bold, Object: Bold, Key: Bold, Illegal: Bold,
+	}
+
+	Bold = []byte("\033[1m")
+)
+
+var themes = map[string]Theme{
+	"default":    DefaultTheme,
+	"dark":       DarkTheme,
+	"light":      LightTheme,
+	"solarized":  SolarizedTheme,
+	"monochrome": MonochromeTheme,
+}
+
+func LookupTheme(name string) (Theme, bool) {
+	theme, ok := themes[name]
+	return theme, ok
+}
+func ThemeNames() (names []string) {
+	for name := range themes {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// Color256 selects a foreground color from the 256-color palette.
+func Color256(n uint8) []byte {
+	return []byte(fmt.Sprintf("\033[38;5;%dm", n))
+}
+
+// TrueColor selects a 24-bit foreground color.
+func TrueColor(r, g, b uint8) []byte {
+	return []byte(fmt.Sprintf("\033[38;2;%d;%d;%dm", r, g, b))
+}
+
+// ParseColors overrides the colors of theme from a specification in the style of
+// JQ_COLORS: colon-separated colors for null:false:true:numbers:strings:arrays:objects:keys,
+// each either SGR parameters (such as "1;31" or "38;5;208") or "#rrggbb" for truecolor.
+// Empty and omitted fields keep the theme's colors.
+func ParseColors(specification string, theme Theme) (Theme, error) {
+	fields := []*[]byte{&theme.Null, &theme.False, &theme.True, &theme.Number, &theme.String, &theme.Array, &theme.Object, &theme.Key}
+	parts := strings.Split(specification, ":")
+	if len(parts) > len(fields) {
+		return theme, fmt.Errorf("too many colors in %q (at most %d)", specification, len(fields))
+	}
+	for x, part := range parts {
+		if part == "" {
+			continue
+		}
+		color, err := parseColor(part)
+		if err != nil {
+			return theme, err
+		}
+		*fields[x] = color
+	}
+	return theme, nil
+}
+func parseColor(text string) ([]byte, error) {
+	if strings.HasPrefix(text, "#") {
+		rgb, err := strconv.ParseUint(text[1:], 16, 32)
+		if err != nil || len(text) != 7 {
+			return nil, fmt.Errorf("invalid color %q (expected #rrggbb)", text)
+		}
+		return TrueColor(uint8(rgb>>16), uint8(rgb>>8), uint8(rgb)), nil
+	}
+	for _, parameter := range strings.Split(text, ";") {
+		if _, err := strconv.ParseUint(parameter, 10, 8); err != nil {
+			return nil, fmt.Errorf("invalid color %q (expected SGR parameters such as 1;31)", text)
+		}
+	}
+	return []byte("\033[" + text + "m"), nil
+}
@@ -0,0 +1,39 @@
+package printing
+
+import (
+	"testing"
+
+	"github.com/mdwhatcott/testing/should"
+)
+
+func TestLookupTheme(t *testing.T) {
+	should.So(t, ThemeNames(), should.Equal, []string{"dark", "default", "light", "monochrome", "solarized"})
+	theme, ok := LookupTheme("default")
+	should.So(t, ok, should.BeTrue)
+	should.So(t, theme, should.Equal, DefaultTheme)
+	_, ok = LookupTheme("neon")
+	should.So(t, ok, should.BeFalse)
+}
+func TestColorCodes(t *testing.T) {
+	should.So(t, string(Color256(208)), should.Equal, "\x1b[38;5;208m")
+	should.So(t, string(TrueColor(1, 2, 255)), should.Equal, "\x1b[38;2;1;2;255m")
+}
+func TestParseColors(t *testing.T) {
+	_, err := ParseColors("1;30::0;32:38;5;208:#ff8000::::", DefaultTheme)
+	should.So(t, err, should.NOT.BeNil)
+	theme, err := ParseColors("1;30::0;32:38;5;208:#ff8000:::34;1", DefaultTheme)
+	should.So(t, err, should.BeNil)
+	should.So(t, string(theme.Null), should.Equal, "\x1b[1;30m")
+	should.So(t, theme.False, should.Equal, DefaultTheme.False)
+	should.So(t, string(theme.True), should.Equal, "\x1b[0;32m")
+	should.So(t, string(theme.Number), should.Equal, "\x1b[38;5;208m")
+	should.So(t, string(theme.String), should.Equal, "\x1b[38;2;255;128;0m")
+	should.So(t, theme.Array, should.Equal, DefaultTheme.Array)
+	should.So(t, string(theme.Key), should.Equal, "\x1b[34;1m")
+	should.So(t, theme.Illegal, should.Equal, DefaultTheme.Illegal)
+
+	_, err = ParseColors("red", DefaultTheme)
+	should.So(t, err.Error(), should.Equal, `invalid color "red" (expected SGR parameters such as 1;31)`)
+	_, err = ParseColors("#abc", DefaultTheme)
+	should.So(t, err.Error(), should.Equal, `invalid color "#abc" (expected #rrggbb)`)
+}
@@ -0,0 +1,183 @@
+package printing
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"unicode"
+
+	"github.com/mdwhatcott/coding-challenges.fyi-json/lib/lexing"
+)
+
+// yaml prints the token stream as a YAML document in block style (with flow
+// style only for empty arrays and objects), preserving the order of keys.
+// Strings are written plain unless a YAML parser might read them as something
+// else (`no`, `1e3`, `- x`, ...), in which case they keep their JSON quotes,
+// which YAML reads the same way.
+type yaml struct {
+	out     io.Writer
+	frames  []yamlFrame
+	pending *lexing.Token // a container start, until we know whether it is empty
+	key     bool          // whether the next string is a key
+	started bool
+}
+
+type yamlFrame struct {
+	array  bool
+	indent int
+	inline bool // whether the first item goes on the current line (after "- ")
+}
+
+func NewYAMLPrinter(out io.Writer) Printer {
+	return &yaml{out: out}
+}
+
+func (this *yaml) Print(token lexing.Token) {
+	if token.Type == lexing.TokenWhitespace {
+		return
+	}
+	if this.pending != nil {
+		opener := *this.pending
+		this.pending = nil
+		if closes(opener, token) {
+			this.value(emptyContainers[opener.Type == lexing.TokenArrayStart])
+			return
+		}
+		this.open(opener)
+	}
+	switch token.Type {
+	case lexing.TokenObjectStart, lexing.TokenArrayStart:
+		this.pending = &token
+	case lexing.TokenObjectStop, lexing.TokenArrayStop:
+		if len(this.frames) > 0 {
+			this.frames = this.frames[:len(this.frames)-1]
+		}
+	case lexing.TokenComma:
+		this.key = len(this.frames) > 0 && !this.frames[len(this.frames)-1].array
+	case lexing.TokenColon:
+	case lexing.TokenString:
+		if this.key {
+			this.item()
+			this.write(yamlString(token.Value))
+			this.write([]byte(":"))
+			this.key = false
+			return
+		}
+		this.value(yamlString(token.Value))
+	case lexing.TokenNumber:
+		this.value(yamlNumber(token.Value))
+	case lexing.TokenTrue, lexing.TokenFalse, lexing.TokenNull:
+		this.value(token.Value)
+	case lexing.TokenIllegal:
+		this.lineBreak()
+		this.write(token.Value)
+	}
+}
+
+// value writes a scalar (or empty container) as the root, as the value of a key, or as an element.
+func (this *yaml) value(text []byte) {
+	if len(this.frames) > 0 {
+		if top := this.frames[len(this.frames)-1]; top.array {
+			this.item()
+		} else {
+			this.write(space)
+		}
+	}
+	this.write(text)
+}
+
+// open begins a non-empty container, whose items go on the lines that follow
+// (indented, unless it is the root), or (after "- ") on the current line.
+func (this *yaml) open(opener lexing.Token) {
+	frame := yamlFrame{array: opener.Type == lexing.TokenArrayStart}
+	if len(this.frames) > 0 {
+		parent := this.frames[len(this.frames)-1]
+		frame.indent = parent.indent + 2
+		if parent.array {
+			this.item()
+			frame.inline = true
+		}
+	}
+	this.frames = append(this.frames, frame)
+	this.key = !frame.array
+}
+
+// item begins an element ("- ") or member of the innermost container.
+func (this *yaml) item() {
+	top := &this.frames[len(this.frames)-1]
+	if top.inline {
+		top.inline = false
+	} else {
+		this.lineBreak()
+		this.write(bytes.Repeat(space, top.indent))
+	}
+	if top.array {
+		this.write([]byte("- "))
+	}
+}
+func (this *yaml) lineBreak() {
+	if this.started {
+		this.write([]byte("\n"))
+	}
+}
+func (this *yaml) write(text []byte) {
+	this.started = true
+	_, _ = this.out.Write(text)
+}
+
+// yamlString returns the plain form of the JSON string token, when that is safe, or the token itself.
+func yamlString(token []byte) []byte {
+	if text := lexing.Unquote(token); plainYAML(text) {
+		return []byte(text)
+	}
+	return token
+}
+
+// plainYAML reports whether text can be written as a plain (unquoted) YAML
+// scalar and read back as the same string, by YAML 1.1 and 1.2 parsers alike.
+// It errs on the side of quoting: anything starting like a number (1e3, 0x1F,
+// 2024-01-01, .5, -1), an indicator (- ? : # & * ! | > ' " % @ `, ...) or a
+// document marker is quoted, as are the words that YAML 1.1 reads as booleans
+// (yes, no, on, off, y, n) or null (~), in any case.
+func plainYAML(text string) bool {
+	if text == "" || text != strings.TrimSpace(text) {
+		return false
+	}
+	if strings.ContainsRune(yamlIndicators, rune(text[0])) || (text[0] >= '0' && text[0] <= '9') {
+		return false
+	}
+	if strings.Contains(text, ": ") || strings.Contains(text, " #") || strings.HasSuffix(text, ":") {
+		return false
+	}
+	for _, c := range text {
+		if !unicode.IsPrint(c) {
+			return false
+		}
+	}
+	switch strings.ToLower(text) {
+	case "null", "true", "false", "yes", "no", "on", "off", "y", "n":
+		return false
+	}
+	return true
+}
+
+const yamlIndicators = "-?:,[]{}#&*!|>'\"%@`+.~<="
+
+// yamlNumber rewrites exponents for YAML 1.1 parsers, which only read
+// numbers like 1.0e+3 (not 1e3) as floats; YAML 1.2 reads both forms.
+func yamlNumber(token []byte) []byte {
+	exponent := bytes.IndexAny(token, "eE")
+	if exponent < 0 {
+		return token
+	}
+	mantissa, power := token[:exponent], token[exponent+1:]
+	result := append([]byte(nil), mantissa...)
+	if !bytes.ContainsRune(mantissa, '.') {
+		result = append(result, ".0"...)
+	}
+	result = append(result, token[exponent])
+	if power[0] != '+' && power[0] != '-' {
+		result = append(result, '+')
+	}
+	return append(result, power...)
+}
@@ -0,0 +1,66 @@
+package printing
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/mdwhatcott/coding-challenges.fyi-json/lib/lexing"
+	"github.com/mdwhatcott/testing/should"
+)
+
+func TestYAMLPrinter(t *testing.T) {
+	testYAML(t, `"hi"`, `hi`)
+	testYAML(t, `1e3`, `1.0e+3`)
+	testYAML(t, `{}`, `{}`)
+	testYAML(t, `[]`, `[]`)
+	testYAML(t, `{"b": 1, "a": [true, null, 2.5E-3], "c": {}, "d": [], "e": {"f": "g"}}`, ""+
+		"b: 1\n"+
+		"a:\n"+
+		"  - true\n"+
+		"  - null\n"+
+		"  - 2.5E-3\n"+
+		"c: {}\n"+
+		"d: []\n"+
+		"e:\n"+
+		"  f: g")
+	testYAML(t, `[{"a": 1, "b": [[1, 2], [], {"c": {"d": 3}}]}, [[4]]]`, ""+
+		"- a: 1\n"+
+		"  b:\n"+
+		"    - - 1\n"+
+		"      - 2\n"+
+		"    - []\n"+
+		"    - c:\n"+
+		"        d: 3\n"+
+		"- - - 4")
+	testYAML(t, `{"no": "on", "yes": "Off", "1e3": "0x1F", "-a": "- a", "": " x", "a: b": "a #b", "2024-01-01": ".5", "~": "null", "tab\there": "line\nbreak", "ünï": "\u00e9 ok", "<<": "a:"}`, ""+
+		`"no": "on"`+"\n"+
+		`"yes": "Off"`+"\n"+
+		`"1e3": "0x1F"`+"\n"+
+		`"-a": "- a"`+"\n"+
+		`"": " x"`+"\n"+
+		`"a: b": "a #b"`+"\n"+
+		`"2024-01-01": ".5"`+"\n"+
+		`"~": "null"`+"\n"+
+		`"tab\there": "line\nbreak"`+"\n"+
+		`ünï: é ok`+"\n"+
+		`"<<": "a:"`)
+	testYAML(t, `{"plain": "a-b:c#d, [e] {f}", "yn": ["y", "N", "Yes", "maybe"]}`, ""+
+		"plain: a-b:c#d, [e] {f}\n"+
+		"yn:\n"+
+		"  - \"y\"\n"+
+		"  - \"N\"\n"+
+		"  - \"Yes\"\n"+
+		"  - maybe")
+	testYAML(t, `{"a": [1, tru`, "a:\n  - 1\ntru")
+}
+func testYAML(t *testing.T, input, expected string) {
+	t.Run(input, func(t *testing.T) {
+		out := &bytes.Buffer{}
+		printer := NewYAMLPrinter(out)
+		for token := range lexing.Lex(strings.NewReader(input)) {
+			printer.Print(token)
+		}
+		should.So(t, out.String(), should.Equal, expected)
+	})
+}
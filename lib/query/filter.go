@@ -0,0 +1,209 @@
+package query
+
+import (
+	"regexp"
+	"strconv"
+	"unicode/utf8"
+
+	"github.com/mdwhatcott/coding-challenges.fyi-json/lib/parsing"
+)
+
+type context struct {
+	root    any
+	current any
+}
+
+type expression interface {
+	test(context context) bool
+}
+
+type orExpression []expression
+
+func (this orExpression) test(context context) bool {
+	for _, expression := range this {
+		if expression.test(context) {
+			return true
+		}
+	}
+	return false
+}
+
+type andExpression []expression
+
+func (this andExpression) test(context context) bool {
+	for _, expression := range this {
+		if !expression.test(context) {
+			return false
+		}
+	}
+	return true
+}
+
+type notExpression struct{ expression }
+
+func (this notExpression) test(context context) bool {
+	return !this.expression.test(context)
+}
+
+type existenceTest struct{ query *queryOperand }
+
+func (this existenceTest) test(context context) bool {
+	return len(this.query.nodes(context)) > 0
+}
+
+type comparison struct {
+	left     operand
+	operator string
+	right    operand
+}
+
+func (this comparison) test(context context) bool {
+	left, leftOK := this.left.evaluate(context)
+	right, rightOK := this.right.evaluate(context)
+	switch this.operator {
+	case "==":
+		return equal(left, leftOK, right, rightOK)
+	case "!=":
+		return !equal(left, leftOK, right, rightOK)
+	case "<":
+		return less(left, leftOK, right, rightOK)
+	case "<=":
+		return less(left, leftOK, right, rightOK) || equal(left, leftOK, right, rightOK)
+	case ">":
+		return less(right, rightOK, left, leftOK)
+	case ">=":
+		return less(right, rightOK, left, leftOK) || equal(left, leftOK, right, rightOK)
+	default:
+		return false
+	}
+}
+
+// equal and less implement the comparison rules of RFC 9535 (section 2.3.5.2.2),
+// where a false ok represents the special result Nothing.
+func equal(a any, aOK bool, b any, bOK bool) bool {
+	if !aOK || !bOK {
+		return aOK == bOK
+	}
+	return parsing.Equal(a, b)
+}
+func less(a any, aOK bool, b any, bOK bool) bool {
+	if !aOK || !bOK {
+		return false
+	}
+	switch a := a.(type) {
+	case parsing.Number:
+		b, ok := b.(parsing.Number)
+		return ok && parsing.CompareNumbers(a, b) < 0
+	case string:
+		b, ok := b.(string)
+		return ok && a < b
+	default:
+		return false
+	}
+}
+
+// operand is a value within a filter expression; a false ok represents Nothing.
+type operand interface {
+	evaluate(context context) (value any, ok bool)
+}
+
+type literalOperand struct{ value any }
+
+func (this literalOperand) evaluate(context) (any, bool) {
+	return this.value, true
+}
+
+type queryOperand struct {
+	absolute bool
+	segments []segment
+}
+
+func (this *queryOperand) nodes(context context) []Node {
+	if this.absolute {
+		return evaluate(this.segments, Node{Value: context.root}, context.root)
+	}
+	return evaluate(this.segments, Node{Value: context.current}, context.root)
+}
+func (this *queryOperand) evaluate(context context) (any, bool) {
+	nodes := this.nodes(context)
+	if len(nodes) != 1 {
+		return nil, false
+	}
+	return nodes[0].Value, true
+}
+func (this *queryOperand) singular() bool {
+	for _, segment := range this.segments {
+		if !segment.singular() {
+			return false
+		}
+	}
+	return true
+}
+
+type functionOperand struct {
+	name      string
+	arguments []operand
+}
+
+func (this *functionOperand) evaluate(context context) (any, bool) {
+	switch this.name {
+	case "length":
+		value, ok := this.arguments[0].evaluate(context)
+		if !ok {
+			return nil, false
+		}
+		switch value := value.(type) {
+		case string:
+			return count(utf8.RuneCountInString(value)), true
+		case []any:
+			return count(len(value)), true
+		case *parsing.Object:
+			return count(value.Len()), true
+		}
+		return nil, false
+	case "count":
+		return count(len(this.arguments[0].(*queryOperand).nodes(context))), true
+	case "value":
+		return this.arguments[0].evaluate(context)
+	default:
+		return nil, false
+	}
+}
+func (this *functionOperand) test(context context) bool {
+	value, ok := this.arguments[0].evaluate(context)
+	text, isText := value.(string)
+	pattern, patternOK := this.arguments[1].evaluate(context)
+	expression, isExpression := pattern.(string)
+	if !ok || !isText || !patternOK || !isExpression {
+		return false
+	}
+	if this.name == "match" {
+		expression = `^(?:` + expression + `)$`
+	}
+	compiled, err := regexp.Compile(expression)
+	return err == nil && compiled.MatchString(text)
+}
+
+func count(n int) parsing.Number {
+	return parsing.Number(strconv.Itoa(n))
+}
+
+// functions lists the function extensions of RFC 9535 (section 2.4) by name,
+// along with their parameter kinds (value or nodes) and whether they return a logical result.
+var functions = map[string]struct {
+	parameters []parameterKind
+	logical    bool
+}{
+	"length": {parameters: []parameterKind{valueParameter}},
+	"count":  {parameters: []parameterKind{nodesParameter}},
+	"match":  {parameters: []parameterKind{valueParameter, valueParameter}, logical: true},
+	"search": {parameters: []parameterKind{valueParameter, valueParameter}, logical: true},
+	"value":  {parameters: []parameterKind{nodesParameter}},
+}
+
+type parameterKind int
+
+const (
+	valueParameter parameterKind = iota
+	nodesParameter
+)
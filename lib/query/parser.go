@@ -0,0 +1,457 @@
+package query
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf16"
+	"unicode/utf8"
+
+	"github.com/mdwhatcott/coding-challenges.fyi-json/lib/parsing"
+)
+
+type SyntaxError struct {
+	Query  string
+	Offset int
+	Reason string
+}
+
+func (this *SyntaxError) Error() string {
+	return fmt.Sprintf("invalid JSONPath query at offset %d (%s): %s", this.Offset, this.Reason, this.Query)
+}
+
+// parser is a recursive-descent parser for the grammar in RFC 9535.
+// Errors are reported by panicking with a *SyntaxError (recovered in Compile).
+type parser struct {
+	text   string
+	offset int
+}
+
+func newParser(text string) *parser {
+	return &parser{text: text}
+}
+
+func (this *parser) fail(format string, args ...any) {
+	panic(&SyntaxError{Query: this.text, Offset: this.offset, Reason: fmt.Sprintf(format, args...)})
+}
+func (this *parser) done() bool {
+	return this.offset >= len(this.text)
+}
+func (this *parser) peek() byte {
+	if this.done() {
+		return 0
+	}
+	return this.text[this.offset]
+}
+func (this *parser) consume(prefix string) bool {
+	if !strings.HasPrefix(this.text[this.offset:], prefix) {
+		return false
+	}
+	this.offset += len(prefix)
+	return true
+}
+func (this *parser) expect(prefix string) {
+	if !this.consume(prefix) {
+		this.fail("expected '%s'", prefix)
+	}
+}
+func (this *parser) skipBlanks() {
+	for strings.IndexByte(" \t\n\r", this.peek()) >= 0 && !this.done() {
+		this.offset++
+	}
+}
+
+func (this *parser) query() *Query {
+	this.expect("$")
+	segments := this.segments()
+	if !this.done() {
+		this.fail("unexpected '%c'", this.peek())
+	}
+	return &Query{text: this.text, segments: segments}
+}
+func (this *parser) segments() (result []segment) {
+	for {
+		start := this.offset
+		this.skipBlanks()
+		switch {
+		case this.consume(".."):
+			result = append(result, segment{descendant: true, selectors: this.descendantSelectors()})
+		case this.consume("."):
+			result = append(result, segment{selectors: this.shorthandSelectors()})
+		case this.consume("["):
+			result = append(result, segment{selectors: this.bracketedSelectors()})
+		default:
+			this.offset = start
+			return result
+		}
+	}
+}
+func (this *parser) descendantSelectors() []selector {
+	if this.consume("[") {
+		return this.bracketedSelectors()
+	}
+	return this.shorthandSelectors()
+}
+func (this *parser) shorthandSelectors() []selector {
+	if this.consume("*") {
+		return []selector{wildcardSelector{}}
+	}
+	return []selector{nameSelector(this.memberName())}
+}
+func (this *parser) memberName() string {
+	start := this.offset
+	for !this.done() {
+		r, size := utf8.DecodeRuneInString(this.text[this.offset:])
+		if !isNameFirst(r) && !(this.offset > start && '0' <= r && r <= '9') {
+			break
+		}
+		this.offset += size
+	}
+	if this.offset == start {
+		this.fail("expected member name")
+	}
+	return this.text[start:this.offset]
+}
+func isNameFirst(r rune) bool {
+	return ('a' <= r && r <= 'z') || ('A' <= r && r <= 'Z') || r == '_' ||
+		(0x80 <= r && r <= 0xD7FF) || (0xE000 <= r && r <= 0x10FFFF)
+}
+
+func (this *parser) bracketedSelectors() (result []selector) {
+	for {
+		this.skipBlanks()
+		result = append(result, this.selector())
+		this.skipBlanks()
+		if this.consume("]") {
+			return result
+		}
+		this.expect(",")
+	}
+}
+func (this *parser) selector() selector {
+	switch c := this.peek(); {
+	case c == '\'' || c == '"':
+		return nameSelector(this.stringLiteral())
+	case c == '*':
+		this.offset++
+		return wildcardSelector{}
+	case c == '?':
+		this.offset++
+		this.skipBlanks()
+		return filterSelector{expression: this.logicalOr()}
+	case c == ':' || c == '-' || ('0' <= c && c <= '9'):
+		return this.indexOrSlice()
+	default:
+		this.fail("expected selector")
+		return nil
+	}
+}
+func (this *parser) indexOrSlice() selector {
+	var start, end *int
+	if this.peek() != ':' {
+		start = this.integer()
+		this.skipBlanks()
+		if this.peek() != ':' {
+			return indexSelector(*start)
+		}
+	}
+	this.expect(":")
+	this.skipBlanks()
+	if c := this.peek(); c == '-' || ('0' <= c && c <= '9') {
+		end = this.integer()
+		this.skipBlanks()
+	}
+	step := 1
+	if this.consume(":") {
+		this.skipBlanks()
+		if c := this.peek(); c == '-' || ('0' <= c && c <= '9') {
+			step = *this.integer()
+		}
+	}
+	return sliceSelector{start: start, end: end, step: step}
+}
+func (this *parser) integer() *int {
+	start := this.offset
+	_ = this.consume("-")
+	digits := this.offset
+	for c := this.peek(); '0' <= c && c <= '9'; c = this.peek() {
+		this.offset++
+	}
+	text := this.text[start:this.offset]
+	if this.offset == digits || (this.text[digits] == '0' && (this.offset-digits > 1 || digits > start)) {
+		this.offset = start
+		this.fail("invalid integer")
+	}
+	value, err := strconv.Atoi(text)
+	if err != nil || value > maxInteger || value < -maxInteger {
+		this.offset = start
+		this.fail("integer out of range")
+	}
+	return &value
+}
+
+const maxInteger = 1<<53 - 1
+
+func (this *parser) stringLiteral() string {
+	quote := this.peek()
+	this.offset++
+	var builder strings.Builder
+	for {
+		if this.done() {
+			this.fail("unterminated string literal")
+		}
+		c := this.peek()
+		switch {
+		case c == quote:
+			this.offset++
+			return builder.String()
+		case c < 0x20:
+			this.fail("control character in string literal")
+		case c == '\\':
+			this.offset++
+			builder.WriteRune(this.escape())
+		default:
+			builder.WriteByte(c)
+			this.offset++
+		}
+	}
+}
+func (this *parser) escape() rune {
+	c := this.peek()
+	this.offset++
+	switch c {
+	case 'b':
+		return '\b'
+	case 'f':
+		return '\f'
+	case 'n':
+		return '\n'
+	case 'r':
+		return '\r'
+	case 't':
+		return '\t'
+	case '/', '\\', '\'', '"':
+		return rune(c)
+	case 'u':
+		r := this.hex4()
+		if utf16.IsSurrogate(r) {
+			if !this.consume(`\u`) {
+				this.fail("unpaired surrogate")
+			}
+			r = utf16.DecodeRune(r, this.hex4())
+			if r == utf8.RuneError {
+				this.fail("invalid surrogate pair")
+			}
+		}
+		return r
+	default:
+		this.offset--
+		this.fail("invalid escape")
+		return 0
+	}
+}
+func (this *parser) hex4() rune {
+	if this.offset+4 > len(this.text) {
+		this.fail("expected four hex digits")
+	}
+	value, err := strconv.ParseUint(this.text[this.offset:this.offset+4], 16, 32)
+	if err != nil {
+		this.fail("expected four hex digits")
+	}
+	this.offset += 4
+	return rune(value)
+}
+
+func (this *parser) logicalOr() expression {
+	result := orExpression{this.logicalAnd()}
+	for {
+		this.skipBlanks()
+		if !this.consume("||") {
+			break
+		}
+		this.skipBlanks()
+		result = append(result, this.logicalAnd())
+	}
+	if len(result) == 1 {
+		return result[0]
+	}
+	return result
+}
+func (this *parser) logicalAnd() expression {
+	result := andExpression{this.basic()}
+	for {
+		this.skipBlanks()
+		if !this.consume("&&") {
+			break
+		}
+		this.skipBlanks()
+		result = append(result, this.basic())
+	}
+	if len(result) == 1 {
+		return result[0]
+	}
+	return result
+}
+func (this *parser) basic() expression {
+	if this.consume("!") {
+		this.skipBlanks()
+		if this.consume("(") {
+			return notExpression{this.parenthesized()}
+		}
+		return notExpression{this.test(this.operand())}
+	}
+	if this.consume("(") {
+		return this.parenthesized()
+	}
+	start := this.offset
+	left := this.operand()
+	this.skipBlanks()
+	for _, operator := range []string{"==", "!=", "<=", ">=", "<", ">"} {
+		if this.consume(operator) {
+			this.skipBlanks()
+			right := this.operand()
+			this.comparable(left, start)
+			this.comparable(right, start)
+			return comparison{left: left, operator: operator, right: right}
+		}
+	}
+	this.offset = start
+	return this.test(this.operand())
+}
+func (this *parser) parenthesized() expression {
+	this.skipBlanks()
+	result := this.logicalOr()
+	this.skipBlanks()
+	this.expect(")")
+	return result
+}
+func (this *parser) test(operand operand) expression {
+	switch operand := operand.(type) {
+	case *queryOperand:
+		return existenceTest{query: operand}
+	case *functionOperand:
+		if functions[operand.name].logical {
+			return operand
+		}
+		this.fail("result of %s() must be compared", operand.name)
+	default:
+		this.fail("literal must be compared")
+	}
+	return nil
+}
+func (this *parser) comparable(operand operand, start int) {
+	switch operand := operand.(type) {
+	case *queryOperand:
+		if !operand.singular() {
+			this.offset = start
+			this.fail("only singular queries may be compared")
+		}
+	case *functionOperand:
+		if functions[operand.name].logical {
+			this.offset = start
+			this.fail("result of %s() cannot be compared", operand.name)
+		}
+	}
+}
+func (this *parser) operand() operand {
+	switch c := this.peek(); {
+	case c == '@':
+		this.offset++
+		return &queryOperand{segments: this.segments()}
+	case c == '$':
+		this.offset++
+		return &queryOperand{absolute: true, segments: this.segments()}
+	case c == '\'' || c == '"':
+		return literalOperand{value: this.stringLiteral()}
+	case c == '-' || ('0' <= c && c <= '9'):
+		return literalOperand{value: this.number()}
+	case this.keyword("true"):
+		return literalOperand{value: true}
+	case this.keyword("false"):
+		return literalOperand{value: false}
+	case this.keyword("null"):
+		return literalOperand{value: nil}
+	case 'a' <= c && c <= 'z':
+		return this.function()
+	default:
+		this.fail("expected operand")
+		return nil
+	}
+}
+func (this *parser) keyword(word string) bool {
+	rest := this.text[this.offset:]
+	if !strings.HasPrefix(rest, word) {
+		return false
+	}
+	if next, _ := utf8.DecodeRuneInString(rest[len(word):]); unicode.IsLetter(next) || unicode.IsDigit(next) || next == '_' || next == '(' {
+		return false
+	}
+	this.offset += len(word)
+	return true
+}
+func (this *parser) number() parsing.Number {
+	start := this.offset
+	_ = this.consume("-")
+	digits := this.digits()
+	if digits == 0 || (digits > 1 && this.text[this.offset-digits] == '0') {
+		this.offset = start
+		this.fail("invalid number")
+	}
+	if this.consume(".") && this.digits() == 0 {
+		this.fail("invalid number")
+	}
+	if this.consume("e") || this.consume("E") {
+		_ = this.consume("-") || this.consume("+")
+		if this.digits() == 0 {
+			this.fail("invalid number")
+		}
+	}
+	return parsing.Number(this.text[start:this.offset])
+}
+func (this *parser) digits() (count int) {
+	for c := this.peek(); '0' <= c && c <= '9'; c = this.peek() {
+		this.offset++
+		count++
+	}
+	return count
+}
+func (this *parser) function() operand {
+	start := this.offset
+	for c := this.peek(); ('a' <= c && c <= 'z') || ('0' <= c && c <= '9') || c == '_'; c = this.peek() {
+		this.offset++
+	}
+	name := this.text[start:this.offset]
+	function, ok := functions[name]
+	if !ok {
+		this.offset = start
+		this.fail("unknown function %q", name)
+	}
+	this.expect("(")
+	result := &functionOperand{name: name}
+	for x, kind := range function.parameters {
+		if x > 0 {
+			this.skipBlanks()
+			this.expect(",")
+		}
+		this.skipBlanks()
+		argumentStart := this.offset
+		argument := this.operand()
+		query, isQuery := argument.(*queryOperand)
+		if kind == nodesParameter && !isQuery {
+			this.offset = argumentStart
+			this.fail("%s() requires a query argument", name)
+		}
+		if kind == valueParameter && isQuery && !query.singular() {
+			this.offset = argumentStart
+			this.fail("%s() requires a singular query argument", name)
+		}
+		if kind == valueParameter {
+			this.comparable(argument, argumentStart)
+		}
+		result.arguments = append(result.arguments, argument)
+	}
+	this.skipBlanks()
+	this.expect(")")
+	return result
+}
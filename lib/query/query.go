@@ -0,0 +1,102 @@
+package query
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/mdwhatcott/coding-challenges.fyi-json/lib/parsing"
+)
+
+// Query is a compiled JSONPath (RFC 9535) query, evaluated against
+// documents produced by the parsing package.
+type Query struct {
+	text     string
+	segments []segment
+}
+
+func Compile(text string) (query *Query, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			syntax, ok := r.(*SyntaxError)
+			if !ok {
+				panic(r)
+			}
+			err = syntax
+		}
+	}()
+	return newParser(text).query(), nil
+}
+
+func (this *Query) String() string {
+	return this.text
+}
+
+// Select returns the nodes of root matched by the query, in the order defined by RFC 9535.
+func (this *Query) Select(root any) []Node {
+	return evaluate(this.segments, Node{Value: root}, root)
+}
+
+// Node is a value within a document along with its location.
+type Node struct {
+	Location []any // member names (string) and array indexes (int) from the root
+	Value    any
+}
+
+func (this Node) child(location any, value any) Node {
+	return Node{Location: append(slices.Clip(this.Location), location), Value: value}
+}
+
+// Path renders the normalized path of the node, such as $['a'][0].
+func (this Node) Path() string {
+	var builder strings.Builder
+	builder.WriteString("$")
+	for _, location := range this.Location {
+		switch location := location.(type) {
+		case int:
+			_, _ = fmt.Fprintf(&builder, "[%d]", location)
+		case string:
+			builder.WriteString("['")
+			builder.WriteString(nameEscaper.Replace(location))
+			builder.WriteString("']")
+		}
+	}
+	return builder.String()
+}
+
+var nameEscaper = strings.NewReplacer(
+	`\`, `\\`, `'`, `\'`, "\b", `\b`, "\f", `\f`, "\n", `\n`, "\r", `\r`, "\t", `\t`,
+	"\x00", `\u0000`, "\x01", `\u0001`, "\x02", `\u0002`, "\x03", `\u0003`,
+	"\x04", `\u0004`, "\x05", `\u0005`, "\x06", `\u0006`, "\x07", `\u0007`,
+	"\x0b", `\u000b`, "\x0e", `\u000e`, "\x0f", `\u000f`, "\x10", `\u0010`,
+	"\x11", `\u0011`, "\x12", `\u0012`, "\x13", `\u0013`, "\x14", `\u0014`,
+	"\x15", `\u0015`, "\x16", `\u0016`, "\x17", `\u0017`, "\x18", `\u0018`,
+	"\x19", `\u0019`, "\x1a", `\u001a`, "\x1b", `\u001b`, "\x1c", `\u001c`,
+	"\x1d", `\u001d`, "\x1e", `\u001e`, "\x1f", `\u001f`,
+)
+
+func evaluate(segments []segment, start Node, root any) []Node {
+	nodes := []Node{start}
+	for _, segment := range segments {
+		var next []Node
+		for _, node := range nodes {
+			next = segment.apply(node, root, next)
+		}
+		nodes = next
+	}
+	return nodes
+}
+
+func children(node Node) (result []Node) {
+	switch value := node.Value.(type) {
+	case []any:
+		for x, element := range value {
+			result = append(result, node.child(x, element))
+		}
+	case *parsing.Object:
+		for _, member := range value.Members {
+			result = append(result, node.child(member.Key, member.Value))
+		}
+	}
+	return result
+}
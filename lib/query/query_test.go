@@ -0,0 +1,175 @@
+package query
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/mdwhatcott/coding-challenges.fyi-json/lib/parsing"
+	"github.com/mdwhatcott/coding-challenges.fyi-json/lib/printing"
+	"github.com/mdwhatcott/testing/should"
+)
+
+const bookstore = `{ "store": {
+    "book": [
+      { "category": "reference", "author": "Nigel Rees", "title": "Sayings of the Century", "price": 8.95 },
+      { "category": "fiction", "author": "Evelyn Waugh", "title": "Sword of Honour", "price": 12.99 },
+      { "category": "fiction", "author": "Herman Melville", "title": "Moby Dick", "isbn": "0-553-21311-3", "price": 8.99 },
+      { "category": "fiction", "author": "J. R. R. Tolkien", "title": "The Lord of the Rings", "isbn": "0-395-19395-8", "price": 22.99 }
+    ],
+    "bicycle": { "color": "red", "price": 399 }
+  }
+}`
+
+func TestBookstore(t *testing.T) {
+	testQuery(t, bookstore, `$.store.book[*].author`,
+		`$['store']['book'][0]['author'] "Nigel Rees"`,
+		`$['store']['book'][1]['author'] "Evelyn Waugh"`,
+		`$['store']['book'][2]['author'] "Herman Melville"`,
+		`$['store']['book'][3]['author'] "J. R. R. Tolkien"`,
+	)
+	testQuery(t, bookstore, `$..author`,
+		`$['store']['book'][0]['author'] "Nigel Rees"`,
+		`$['store']['book'][1]['author'] "Evelyn Waugh"`,
+		`$['store']['book'][2]['author'] "Herman Melville"`,
+		`$['store']['book'][3]['author'] "J. R. R. Tolkien"`,
+	)
+	testQuery(t, bookstore, `$.store..price`,
+		`$['store']['book'][0]['price'] 8.95`,
+		`$['store']['book'][1]['price'] 12.99`,
+		`$['store']['book'][2]['price'] 8.99`,
+		`$['store']['book'][3]['price'] 22.99`,
+		`$['store']['bicycle']['price'] 399`,
+	)
+	testQuery(t, bookstore, `$..book[2].title`, `$['store']['book'][2]['title'] "Moby Dick"`)
+	testQuery(t, bookstore, `$..book[-1].title`, `$['store']['book'][3]['title'] "The Lord of the Rings"`)
+	testQuery(t, bookstore, `$..book[0,1].title`,
+		`$['store']['book'][0]['title'] "Sayings of the Century"`,
+		`$['store']['book'][1]['title'] "Sword of Honour"`,
+	)
+	testQuery(t, bookstore, `$..book[:2].title`,
+		`$['store']['book'][0]['title'] "Sayings of the Century"`,
+		`$['store']['book'][1]['title'] "Sword of Honour"`,
+	)
+	testQuery(t, bookstore, `$..book[?@.isbn].title`,
+		`$['store']['book'][2]['title'] "Moby Dick"`,
+		`$['store']['book'][3]['title'] "The Lord of the Rings"`,
+	)
+	testQuery(t, bookstore, `$..book[?@.price<10].title`,
+		`$['store']['book'][0]['title'] "Sayings of the Century"`,
+		`$['store']['book'][2]['title'] "Moby Dick"`,
+	)
+	testQuery(t, bookstore, `$.store.book[?@.price > 10 && @.category == 'fiction'].author`,
+		`$['store']['book'][1]['author'] "Evelyn Waugh"`,
+		`$['store']['book'][3]['author'] "J. R. R. Tolkien"`,
+	)
+	testQuery(t, bookstore, `$.store.book[?!(@.price > 10) || @.author == "J. R. R. Tolkien"].price`,
+		`$['store']['book'][0]['price'] 8.95`,
+		`$['store']['book'][2]['price'] 8.99`,
+		`$['store']['book'][3]['price'] 22.99`,
+	)
+	testQuery(t, bookstore, `$.store.book[?@.price == $.store.book[0].price].title`,
+		`$['store']['book'][0]['title'] "Sayings of the Century"`,
+	)
+	testQuery(t, bookstore, `$.store.bicycle[*]`,
+		`$['store']['bicycle']['color'] "red"`,
+		`$['store']['bicycle']['price'] 399`,
+	)
+	testQuery(t, bookstore, `$.nope`)
+}
+func TestSlices(t *testing.T) {
+	const array = `["a", "b", "c", "d", "e", "f", "g"]`
+	testQuery(t, array, `$[1:3]`, `$[1] "b"`, `$[2] "c"`)
+	testQuery(t, array, `$[5:]`, `$[5] "f"`, `$[6] "g"`)
+	testQuery(t, array, `$[1:5:2]`, `$[1] "b"`, `$[3] "d"`)
+	testQuery(t, array, `$[5:1:-2]`, `$[5] "f"`, `$[3] "d"`)
+	testQuery(t, array, `$[::-1]`, `$[6] "g"`, `$[5] "f"`, `$[4] "e"`, `$[3] "d"`, `$[2] "c"`, `$[1] "b"`, `$[0] "a"`)
+	testQuery(t, array, `$[-2:]`, `$[5] "f"`, `$[6] "g"`)
+	testQuery(t, array, `$[0:100:0]`)
+	testQuery(t, array, `$[-100:1]`, `$[0] "a"`)
+	testQuery(t, array, `$[7]`)
+}
+func TestFilters(t *testing.T) {
+	const document = `{"a": [3, 5, 1, 2, 4, 6, {"b": "j"}, {"b": "k"}, {"b": {}}, {"b": "kilo"}]}`
+	testQuery(t, document, `$.a[?@.b == 'kilo']`, `$['a'][9] {"b":"kilo"}`)
+	testQuery(t, document, `$.a[?@>3.5]`, `$['a'][1] 5`, `$['a'][4] 4`, `$['a'][5] 6`)
+	testQuery(t, document, `$.a[?@.b]`,
+		`$['a'][6] {"b":"j"}`, `$['a'][7] {"b":"k"}`, `$['a'][8] {"b":{}}`, `$['a'][9] {"b":"kilo"}`)
+	testQuery(t, document, `$.a[?@.b == $.x]`, `$['a'][0] 3`, `$['a'][1] 5`, `$['a'][2] 1`, `$['a'][3] 2`, `$['a'][4] 4`, `$['a'][5] 6`)
+	testQuery(t, document, `$.a[?@ < 2 || @.b == "k"]`, `$['a'][2] 1`, `$['a'][7] {"b":"k"}`)
+	testQuery(t, document, `$.a[?@.b == $.a[8].b]`, `$['a'][8] {"b":{}}`)
+
+	const huge = `[{"price": 1e400}, {"price": 1e398}, {"price": 1e-400}, {"price": 0}]`
+	testQuery(t, huge, `$[?@.price > 1e399].price`, `$[0]['price'] 1e400`)
+	testQuery(t, huge, `$[?@.price < 1e399 && @.price >= 1e-400].price`, `$[1]['price'] 1e398`, `$[2]['price'] 1e-400`)
+	testQuery(t, huge, `$[?@.price == 10e399].price`, `$[0]['price'] 1e400`)
+	testQuery(t, huge, `$[?@.price > 0].price`, `$[0]['price'] 1e400`, `$[1]['price'] 1e398`, `$[2]['price'] 1e-400`)
+	testQuery(t, document, `$.a[?@.b < 'k']`, `$['a'][6] {"b":"j"}`)
+	testQuery(t, document, `$[?@[?@.b]]`, `$['a'] [3,5,1,2,4,6,{"b":"j"},{"b":"k"},{"b":{}},{"b":"kilo"}]`)
+}
+func TestFunctions(t *testing.T) {
+	const document = `[{"a": "ab", "b": [1, 2]}, {"a": "abc", "b": [1]}, {"a": "b", "b": {"x": 1, "y": 2, "z": 3}}]`
+	testQuery(t, document, `$[?length(@.a) == 2].a`, `$[0]['a'] "ab"`)
+	testQuery(t, document, `$[?length(@.b) >= 2].a`, `$[0]['a'] "ab"`, `$[2]['a'] "b"`)
+	testQuery(t, document, `$[?count(@.b[*]) == 1].a`, `$[1]['a'] "abc"`)
+	testQuery(t, document, `$[?match(@.a, 'a.')].a`, `$[0]['a'] "ab"`)
+	testQuery(t, document, `$[?search(@.a, 'b')].a`, `$[0]['a'] "ab"`, `$[1]['a'] "abc"`, `$[2]['a'] "b"`)
+	testQuery(t, document, `$[?!search(@.a, '^a')].a`, `$[2]['a'] "b"`)
+	testQuery(t, document, `$[?value(@..x) == 1].a`, `$[2]['a'] "b"`)
+}
+func TestNormalizedPaths(t *testing.T) {
+	testQuery(t, `{"it's": {"a\\b\n": 1}}`, `$..*`,
+		`$['it\'s'] {"a\\b\n":1}`,
+		`$['it\'s']['a\\b\n'] 1`,
+	)
+	testQuery(t, `{"\u0001": 1}`, `$["\u0001"]`, `$['\u0001'] 1`)
+	testQuery(t, `{"日本": 1}`, `$.日本`, `$['日本'] 1`)
+}
+func testQuery(t *testing.T, document, query string, expected ...string) {
+	t.Run(query, func(t *testing.T) {
+		compiled, err := Compile(query)
+		should.So(t, err, should.BeNil)
+		root, err := parsing.ParseBytes([]byte(document))
+		should.So(t, err, should.BeNil)
+		var actual []string
+		for _, node := range compiled.Select(root) {
+			out := &bytes.Buffer{}
+			parsing.Render(node.Value, printing.NewCompactPrinter(out))
+			actual = append(actual, node.Path()+" "+out.String())
+		}
+		should.So(t, actual, should.Equal, expected)
+	})
+}
+
+func TestCompileErrors(t *testing.T) {
+	for _, query := range []string{
+		``,
+		`.a`,
+		`$.`,
+		`$a`,
+		`$[`,
+		`$[1`,
+		`$[01]`,
+		`$[-0]`,
+		`$['a`,
+		`$['\x']`,
+		`$[9007199254740992]`,
+		`$..`,
+		`$[?@.a == ]`,
+		`$[?@.* == 1]`,
+		`$[?@..a == 1]`,
+		`$[?1]`,
+		`$[?length(@.a)]`,
+		`$[?match(@.a, 'a') == true]`,
+		`$[?count(1) == 1]`,
+		`$[?nope(@)]`,
+		`$[?(@.a]`,
+		`$.a b`,
+	} {
+		t.Run(query, func(t *testing.T) {
+			_, err := Compile(query)
+			should.So(t, err, should.NOT.BeNil)
+			should.So(t, strings.Contains(err.Error(), "invalid JSONPath query"), should.BeTrue)
+		})
+	}
+}
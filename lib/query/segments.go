@@ -0,0 +1,120 @@
+package query
+
+import "github.com/mdwhatcott/coding-challenges.fyi-json/lib/parsing"
+
+type segment struct {
+	descendant bool
+	selectors  []selector
+}
+
+func (this segment) apply(node Node, root any, output []Node) []Node {
+	for _, selector := range this.selectors {
+		output = selector.apply(node, root, output)
+	}
+	if this.descendant {
+		for _, child := range children(node) {
+			output = this.apply(child, root, output)
+		}
+	}
+	return output
+}
+
+// singular reports whether the segment can select at most one node.
+func (this segment) singular() bool {
+	if this.descendant || len(this.selectors) != 1 {
+		return false
+	}
+	switch this.selectors[0].(type) {
+	case nameSelector, indexSelector:
+		return true
+	default:
+		return false
+	}
+}
+
+type selector interface {
+	apply(node Node, root any, output []Node) []Node
+}
+
+type nameSelector string
+
+func (this nameSelector) apply(node Node, _ any, output []Node) []Node {
+	if object, ok := node.Value.(*parsing.Object); ok {
+		if value, ok := object.Get(string(this)); ok {
+			output = append(output, node.child(string(this), value))
+		}
+	}
+	return output
+}
+
+type wildcardSelector struct{}
+
+func (wildcardSelector) apply(node Node, _ any, output []Node) []Node {
+	return append(output, children(node)...)
+}
+
+type indexSelector int
+
+func (this indexSelector) apply(node Node, _ any, output []Node) []Node {
+	array, ok := node.Value.([]any)
+	if !ok {
+		return output
+	}
+	index := int(this)
+	if index < 0 {
+		index += len(array)
+	}
+	if index < 0 || index >= len(array) {
+		return output
+	}
+	return append(output, node.child(index, array[index]))
+}
+
+type sliceSelector struct {
+	start, end *int
+	step       int
+}
+
+func (this sliceSelector) apply(node Node, _ any, output []Node) []Node {
+	array, ok := node.Value.([]any)
+	if !ok || this.step == 0 {
+		return output
+	}
+	length := len(array)
+	normalize := func(index *int, fallback int) int {
+		if index == nil {
+			return fallback
+		}
+		if *index < 0 {
+			return length + *index
+		}
+		return *index
+	}
+	if this.step > 0 {
+		lower := min(max(normalize(this.start, 0), 0), length)
+		upper := min(max(normalize(this.end, length), 0), length)
+		for x := lower; x < upper; x += this.step {
+			output = append(output, node.child(x, array[x]))
+		}
+	} else {
+		upper := min(max(normalize(this.start, length-1), -1), length-1)
+		lower := min(max(normalize(this.end, -length-1), -1), length-1)
+		for x := upper; lower < x; x += this.step {
+			output = append(output, node.child(x, array[x]))
+		}
+	}
+	return output
+}
+
+type filterSelector struct {
+	expression expression
+}
+
+func (this filterSelector) apply(node Node, root any, output []Node) []Node {
+	for _, child := range children(node) {
+		if this.expression.test(context{root: root, current: child.Value}) {
+			output = append(output, child)
+		}
+	}
+	return output
+}
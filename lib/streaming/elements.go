@@ -0,0 +1,57 @@
+package streaming
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/mdwhatcott/coding-challenges.fyi-json/lib/lexing"
+	"github.com/mdwhatcott/coding-challenges.fyi-json/lib/pointer"
+)
+
+// Elements walks into the array at target and calls yield with the raw bytes of
+// each of its elements (without surrounding whitespace), one at a time. The raw
+// slice is only valid during the call to yield. Reading stops once the array is
+// closed or yield returns an error, which Elements then returns.
+func Elements(source io.Reader, target pointer.Pointer, yield func(raw []byte) error) error {
+	done := make(chan struct{})
+	defer close(done)
+	var (
+		tracker pointer.Tracker
+		element []byte
+		inside  bool
+		offset  int
+	)
+	for token := range lexing.LexUntil(source, done) {
+		if token.Type == lexing.TokenIllegal {
+			return &SyntaxError{Offset: offset, Value: token.Value}
+		}
+		offset += len(token.Value)
+		depth := tracker.Depth()
+		step := tracker.Step(token)
+		if !inside {
+			if step.Begin && step.Pointer.Equal(target) {
+				if token.Type != lexing.TokenArrayStart {
+					return fmt.Errorf("value at JSON pointer %q is not an array", target)
+				}
+				inside = true
+			}
+			continue
+		}
+		if step.End && len(step.Pointer) == len(target) {
+			return nil
+		}
+		if depth > len(target)+1 || (step.Begin && depth == len(target)+1) {
+			element = append(element, token.Value...)
+		}
+		if step.End && len(step.Pointer) == len(target)+1 {
+			if err := yield(element); err != nil {
+				return err
+			}
+			element = element[:0]
+		}
+	}
+	if !inside {
+		return fmt.Errorf("no value at JSON pointer %q", target)
+	}
+	return nil
+}
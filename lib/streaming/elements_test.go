@@ -0,0 +1,68 @@
+package streaming
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/mdwhatcott/coding-challenges.fyi-json/lib/pointer"
+	"github.com/mdwhatcott/testing/should"
+)
+
+func TestElementsSuite(t *testing.T) {
+	should.Run(&ElementsSuite{T: should.New(t)}, should.Options.UnitTests())
+}
+
+type ElementsSuite struct {
+	*should.T
+	elements []string
+}
+
+func (this *ElementsSuite) Setup() {
+	this.elements = nil
+}
+func (this *ElementsSuite) split(input string, target ...string) error {
+	return Elements(strings.NewReader(input), target, func(raw []byte) error {
+		this.elements = append(this.elements, string(raw))
+		return nil
+	})
+}
+
+func (this *ElementsSuite) TestTopLevelArray() {
+	err := this.split(` [ 1 , "two",{"three": [3, 3]} ,[ ], null ] `)
+	this.So(err, should.BeNil)
+	this.So(this.elements, should.Equal, []string{`1`, `"two"`, `{"three": [3, 3]}`, `[ ]`, `null`})
+}
+func (this *ElementsSuite) TestEmptyArray() {
+	this.So(this.split(`[]`), should.BeNil)
+	this.So(this.elements, should.BeEmpty)
+}
+func (this *ElementsSuite) TestNestedArray() {
+	err := this.split(`{"meta": [0], "data": {"items": [{"a": 1}, {"a": 2}]}}`, "data", "items")
+	this.So(err, should.BeNil)
+	this.So(this.elements, should.Equal, []string{`{"a": 1}`, `{"a": 2}`})
+}
+func (this *ElementsSuite) TestStopsReadingAfterArray() {
+	err := this.split(`{"items": [1, 2], "rest": [}`, "items")
+	this.So(err, should.BeNil)
+	this.So(this.elements, should.Equal, []string{`1`, `2`})
+}
+func (this *ElementsSuite) TestNotAnArray() {
+	this.So(this.split(`{"items": {}}`, "items"), should.NOT.BeNil)
+}
+func (this *ElementsSuite) TestMissing() {
+	this.So(this.split(`{"items": []}`, "nope"), should.NOT.BeNil)
+}
+func (this *ElementsSuite) TestSyntaxError() {
+	var syntax *SyntaxError
+	this.So(errors.As(this.split(`[1, 2,]`), &syntax), should.BeTrue)
+	this.So(this.elements, should.Equal, []string{`1`, `2`})
+}
+func (this *ElementsSuite) TestYieldAborts() {
+	err := Elements(strings.NewReader(`[1, 2, 3]`), pointer.Pointer{}, func(raw []byte) error {
+		this.elements = append(this.elements, string(raw))
+		return errAbort
+	})
+	this.So(err, should.WrapError, errAbort)
+	this.So(this.elements, should.Equal, []string{`1`})
+}
@@ -0,0 +1,41 @@
+package streaming
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/mdwhatcott/coding-challenges.fyi-json/lib/lexing"
+	"github.com/mdwhatcott/coding-challenges.fyi-json/lib/pointer"
+	"github.com/mdwhatcott/coding-challenges.fyi-json/lib/printing"
+)
+
+// Extract sends the tokens of the value at target to printer, and stops
+// reading from source as soon as that value is complete.
+func Extract(source io.Reader, target pointer.Pointer, printer printing.Printer) error {
+	done := make(chan struct{})
+	defer close(done)
+	var (
+		tracker pointer.Tracker
+		inside  bool
+		offset  int
+	)
+	for token := range lexing.LexUntil(source, done) {
+		if token.Type == lexing.TokenIllegal {
+			return &SyntaxError{Offset: offset, Value: token.Value}
+		}
+		offset += len(token.Value)
+		depth := tracker.Depth()
+		step := tracker.Step(token)
+		if step.Begin && !inside && step.Pointer.Equal(target) {
+			inside = true
+		}
+		if !inside || (depth <= len(target) && !step.Begin && !step.End) {
+			continue
+		}
+		printer.Print(token)
+		if step.End && step.Pointer.Equal(target) {
+			return nil
+		}
+	}
+	return fmt.Errorf("no value at JSON pointer %q", target)
+}
@@ -0,0 +1,57 @@
+package streaming
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/mdwhatcott/coding-challenges.fyi-json/lib/printing"
+	"github.com/mdwhatcott/testing/should"
+)
+
+func TestExtractSuite(t *testing.T) {
+	should.Run(&ExtractSuite{T: should.New(t)}, should.Options.UnitTests())
+}
+
+type ExtractSuite struct {
+	*should.T
+	output *bytes.Buffer
+}
+
+func (this *ExtractSuite) Setup() {
+	this.output = &bytes.Buffer{}
+}
+func (this *ExtractSuite) extract(input string, target ...string) error {
+	return Extract(strings.NewReader(input), target, printing.NewVerbatimPrinter(this.output))
+}
+
+const extractInput = ` {"a": {"b": [1, { "c" : true }, "x"]}, "a/b": null, "": 2} `
+
+func (this *ExtractSuite) TestRoot() {
+	this.So(this.extract(extractInput), should.BeNil)
+	this.So(this.output.String(), should.Equal, `{"a": {"b": [1, { "c" : true }, "x"]}, "a/b": null, "": 2}`)
+}
+func (this *ExtractSuite) TestNestedContainer() {
+	this.So(this.extract(extractInput, "a", "b", "1"), should.BeNil)
+	this.So(this.output.String(), should.Equal, `{ "c" : true }`)
+}
+func (this *ExtractSuite) TestNestedScalar() {
+	this.So(this.extract(extractInput, "a", "b", "2"), should.BeNil)
+	this.So(this.output.String(), should.Equal, `"x"`)
+}
+func (this *ExtractSuite) TestEscapedAndEmptyKeys() {
+	this.So(this.extract(extractInput, "a/b"), should.BeNil)
+	this.So(this.extract(extractInput, ""), should.BeNil)
+	this.So(this.output.String(), should.Equal, `null2`)
+}
+func (this *ExtractSuite) TestStopsReadingOnceComplete() {
+	this.So(this.extract(`[[1, 2], garbage`, "0"), should.BeNil)
+	this.So(this.output.String(), should.Equal, `[1, 2]`)
+}
+func (this *ExtractSuite) TestMissing() {
+	this.So(this.extract(extractInput, "a", "b", "3"), should.NOT.BeNil)
+	this.So(this.output.String(), should.BeEmpty)
+}
+func (this *ExtractSuite) TestSyntaxError() {
+	this.So(this.extract(`{"a": [1,]}`, "a"), should.NOT.BeNil)
+}
@@ -0,0 +1,122 @@
+package streaming
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/mdwhatcott/coding-challenges.fyi-json/lib/lexing"
+)
+
+// Handler receives structural events as a document is lexed.
+// Returning a non-nil error from any method aborts the walk.
+type Handler interface {
+	StartObject() error
+	Key(key string) error
+	EndObject() error
+	StartArray() error
+	EndArray() error
+	String(value string) error
+	Number(value string) error
+	Bool(value bool) error
+	Null() error
+}
+
+// Locator may be implemented by a Handler to be told the byte offset of each
+// token before any event that token produces.
+type Locator interface {
+	Locate(offset int)
+}
+
+// NopHandler ignores all events. Embed it to implement only the events of interest.
+type NopHandler struct{}
+
+func (NopHandler) StartObject() error  { return nil }
+func (NopHandler) Key(string) error    { return nil }
+func (NopHandler) EndObject() error    { return nil }
+func (NopHandler) StartArray() error   { return nil }
+func (NopHandler) EndArray() error     { return nil }
+func (NopHandler) String(string) error { return nil }
+func (NopHandler) Number(string) error { return nil }
+func (NopHandler) Bool(bool) error     { return nil }
+func (NopHandler) Null() error         { return nil }
+
+type SyntaxError struct {
+	Offset int
+	Value  []byte
+}
+
+func (this *SyntaxError) Error() string {
+	return fmt.Sprintf("illegal token at index %d: %s", this.Offset, this.Value)
+}
+
+// Walk lexes source and drives handler with the resulting events. Lexing
+// stops as soon as handler returns an error, which Walk then returns.
+// Illegal input results in a *SyntaxError.
+func Walk(source io.Reader, handler Handler) error {
+	done := make(chan struct{})
+	defer close(done)
+	walker := NewWalker(handler)
+	for token := range lexing.LexUntil(source, done) {
+		if err := walker.Walk(token); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Walker drives a Handler one token at a time, for callers that consume the token stream themselves.
+type Walker struct {
+	handler Handler
+	locator Locator
+	offset  int
+	stack   []lexing.TokenType
+	key     bool
+}
+
+func NewWalker(handler Handler) *Walker {
+	locator, _ := handler.(Locator)
+	return &Walker{handler: handler, locator: locator}
+}
+
+func (this *Walker) Walk(token lexing.Token) error {
+	offset := this.offset
+	this.offset += len(token.Value)
+	if this.locator != nil {
+		this.locator.Locate(offset)
+	}
+	switch token.Type {
+	case lexing.TokenIllegal:
+		return &SyntaxError{Offset: offset, Value: token.Value}
+	case lexing.TokenObjectStart:
+		this.stack = append(this.stack, token.Type)
+		this.key = true
+		return this.handler.StartObject()
+	case lexing.TokenObjectStop:
+		this.stack = this.stack[:len(this.stack)-1]
+		return this.handler.EndObject()
+	case lexing.TokenArrayStart:
+		this.stack = append(this.stack, token.Type)
+		this.key = false
+		return this.handler.StartArray()
+	case lexing.TokenArrayStop:
+		this.stack = this.stack[:len(this.stack)-1]
+		return this.handler.EndArray()
+	case lexing.TokenComma:
+		this.key = this.stack[len(this.stack)-1] == lexing.TokenObjectStart
+	case lexing.TokenString:
+		if this.key {
+			this.key = false
+			return this.handler.Key(lexing.Unquote(token.Value))
+		}
+		return this.handler.String(lexing.Unquote(token.Value))
+	case lexing.TokenNumber:
+		return this.handler.Number(string(token.Value))
+	case lexing.TokenTrue:
+		return this.handler.Bool(true)
+	case lexing.TokenFalse:
+		return this.handler.Bool(false)
+	case lexing.TokenNull:
+		return this.handler.Null()
+	}
+	return nil
+}
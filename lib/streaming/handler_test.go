@@ -0,0 +1,123 @@
+package streaming
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/mdwhatcott/testing/should"
+)
+
+func TestWalkSuite(t *testing.T) {
+	should.Run(&WalkSuite{T: should.New(t)}, should.Options.UnitTests())
+}
+
+type WalkSuite struct {
+	*should.T
+	handler *recordingHandler
+}
+
+func (this *WalkSuite) Setup() {
+	this.handler = &recordingHandler{}
+}
+func (this *WalkSuite) walk(input string) error {
+	return Walk(strings.NewReader(input), this.handler)
+}
+
+func (this *WalkSuite) TestScalars() {
+	this.So(this.walk(`null`), should.BeNil)
+	this.So(this.walk(` true `), should.BeNil)
+	this.So(this.walk(`false`), should.BeNil)
+	this.So(this.walk(`-1.5e3`), should.BeNil)
+	this.So(this.walk(`"a\nb"`), should.BeNil)
+	this.So(this.handler.events, should.Equal, []string{
+		"Null", "Bool:true", "Bool:false", "Number:-1.5e3", "String:a\nb",
+	})
+}
+func (this *WalkSuite) TestContainers() {
+	this.So(this.walk(`{"a": [1, {"b": "c"}, []], "de": {}}`), should.BeNil)
+	this.So(this.handler.events, should.Equal, []string{
+		"StartObject",
+		"Key:a",
+		"StartArray",
+		"Number:1",
+		"StartObject",
+		"Key:b",
+		"String:c",
+		"EndObject",
+		"StartArray",
+		"EndArray",
+		"EndArray",
+		"Key:de",
+		"StartObject",
+		"EndObject",
+		"EndObject",
+	})
+}
+func (this *WalkSuite) TestSyntaxError() {
+	err := this.walk(`[1, 2,]`)
+	var syntax *SyntaxError
+	this.So(errors.As(err, &syntax), should.BeTrue)
+	this.So(syntax.Offset, should.Equal, 6)
+	this.So(string(syntax.Value), should.Equal, "]")
+}
+func (this *WalkSuite) TestHandlerAbortsWalk() {
+	this.handler.failOn = "Number:2"
+	err := this.walk(`[1, 2, 3]`)
+	this.So(err, should.WrapError, errAbort)
+	this.So(this.handler.events, should.Equal, []string{"StartArray", "Number:1", "Number:2"})
+}
+func (this *WalkSuite) TestLocator() {
+	handler := &locatingHandler{}
+	this.So(Walk(strings.NewReader(`{"a": [1, "b"]}`), handler), should.BeNil)
+	this.So(handler.keys, should.Equal, []int{1})
+	this.So(handler.strings, should.Equal, []int{10})
+}
+
+var errAbort = errors.New("abort")
+
+type recordingHandler struct {
+	events []string
+	failOn string
+}
+
+func (this *recordingHandler) record(event string) error {
+	this.events = append(this.events, event)
+	if event == this.failOn {
+		return errAbort
+	}
+	return nil
+}
+func (this *recordingHandler) StartObject() error   { return this.record("StartObject") }
+func (this *recordingHandler) Key(key string) error { return this.record("Key:" + key) }
+func (this *recordingHandler) EndObject() error     { return this.record("EndObject") }
+func (this *recordingHandler) StartArray() error    { return this.record("StartArray") }
+func (this *recordingHandler) EndArray() error      { return this.record("EndArray") }
+func (this *recordingHandler) String(value string) error {
+	return this.record("String:" + value)
+}
+func (this *recordingHandler) Number(value string) error {
+	return this.record("Number:" + value)
+}
+func (this *recordingHandler) Bool(value bool) error {
+	return this.record(fmt.Sprint("Bool:", value))
+}
+func (this *recordingHandler) Null() error { return this.record("Null") }
+
+type locatingHandler struct {
+	NopHandler
+	offset  int
+	keys    []int
+	strings []int
+}
+
+func (this *locatingHandler) Locate(offset int) { this.offset = offset }
+func (this *locatingHandler) Key(string) error {
+	this.keys = append(this.keys, this.offset)
+	return nil
+}
+func (this *locatingHandler) String(string) error {
+	this.strings = append(this.strings, this.offset)
+	return nil
+}
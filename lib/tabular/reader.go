@@ -0,0 +1,85 @@
+package tabular
+
+import (
+	"encoding/csv"
+	"io"
+	"regexp"
+	"strings"
+
+	"github.com/mdwhatcott/coding-challenges.fyi-json/lib/parsing"
+)
+
+// Options control how a Reader interprets cells, which are strings unless
+// one of the inferences applies.
+type Options struct {
+	Comma    rune // between cells (default ',')
+	Numbers  bool // cells that are JSON numbers become numbers (but not "007", "+1" or ".5")
+	Booleans bool // "true" and "false" become booleans
+	Nulls    bool // empty cells become null
+}
+
+// Reader reads CSV (RFC 4180) or TSV with a header row, one object per
+// following row, keyed by the header in order. Every row must have as many
+// cells as the header. TSV cells may be quoted as in CSV (to hold tabs or line
+// breaks), but quotes elsewhere in them (as in `5" screen`) are read as they are.
+type Reader struct {
+	reader  *csv.Reader
+	options Options
+	header  []string
+}
+
+func NewReader(input io.Reader, options Options) *Reader {
+	reader := csv.NewReader(input)
+	if options.Comma != 0 {
+		reader.Comma = options.Comma
+	}
+	reader.LazyQuotes = reader.Comma == '\t'
+	reader.ReuseRecord = true
+	return &Reader{reader: reader, options: options}
+}
+
+// Header returns the column names, reading them if no row has been read yet.
+func (this *Reader) Header() ([]string, error) {
+	if this.header != nil {
+		return this.header, nil
+	}
+	record, err := this.reader.Read()
+	if err != nil {
+		return nil, err
+	}
+	this.header = append([]string{}, record...)
+	this.header[0] = strings.TrimPrefix(this.header[0], byteOrderMark)
+	return this.header, nil
+}
+
+// Read returns the object of the next row, or io.EOF after the last one.
+func (this *Reader) Read() (*parsing.Object, error) {
+	header, err := this.Header()
+	if err != nil {
+		return nil, err
+	}
+	record, err := this.reader.Read()
+	if err != nil {
+		return nil, err
+	}
+	object := &parsing.Object{Members: make([]parsing.Member, len(header))}
+	for x, key := range header {
+		object.Members[x] = parsing.Member{Key: key, Value: this.value(record[x])}
+	}
+	return object, nil
+}
+func (this *Reader) value(cell string) any {
+	switch {
+	case this.options.Nulls && cell == "":
+		return nil
+	case this.options.Booleans && (cell == "true" || cell == "false"):
+		return cell == "true"
+	case this.options.Numbers && number.MatchString(cell):
+		return parsing.Number(cell)
+	}
+	return cell
+}
+
+const byteOrderMark = "\uFEFF"
+
+var number = regexp.MustCompile(`^-?(0|[1-9][0-9]*)(\.[0-9]+)?([eE][-+]?[0-9]+)?$`)
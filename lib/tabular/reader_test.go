@@ -0,0 +1,83 @@
+package tabular
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/mdwhatcott/coding-challenges.fyi-json/lib/parsing"
+	"github.com/mdwhatcott/coding-challenges.fyi-json/lib/printing"
+	"github.com/mdwhatcott/testing/should"
+)
+
+const example = "\uFEFFname,zip,age,admin,note\n" +
+	"bob,007,42,true,\n" +
+	"\"smith, \"\"al\"\"\",12345,-1.5e3,false,\"line\nbreak\"\n" +
+	"eve,+1,.5,True,null\n"
+
+func TestReader_Strings(t *testing.T) {
+	testReader(t, example, Options{},
+		`{"name":"bob","zip":"007","age":"42","admin":"true","note":""}`,
+		`{"name":"smith, \"al\"","zip":"12345","age":"-1.5e3","admin":"false","note":"line\nbreak"}`,
+		`{"name":"eve","zip":"+1","age":".5","admin":"True","note":"null"}`,
+	)
+}
+func TestReader_Inference(t *testing.T) {
+	testReader(t, example, Options{Numbers: true, Booleans: true, Nulls: true},
+		`{"name":"bob","zip":"007","age":42,"admin":true,"note":null}`,
+		`{"name":"smith, \"al\"","zip":12345,"age":-1.5e3,"admin":false,"note":"line\nbreak"}`,
+		`{"name":"eve","zip":"+1","age":".5","admin":"True","note":"null"}`,
+	)
+	testReader(t, "a,b,c\n1,true,\n", Options{Numbers: true},
+		`{"a":1,"b":"true","c":""}`)
+}
+func TestReader_TSV(t *testing.T) {
+	testReader(t, "a\tb\r\nx y\t\"1\t2\"\r\n", Options{Comma: '\t'},
+		`{"a":"x y","b":"1\t2"}`)
+	testReader(t, "size\tname\n5\"\t5\" screen\n\"\"\"6\"\"\"\tsay \"hi\"\n", Options{Comma: '\t'},
+		`{"size":"5\"","name":"5\" screen"}`,
+		`{"size":"\"6\"","name":"say \"hi\""}`)
+}
+func TestReader_Empty(t *testing.T) {
+	testReader(t, "", Options{})
+	testReader(t, "a,b\n", Options{})
+}
+func testReader(t *testing.T, input string, options Options, expected ...string) {
+	t.Run(input, func(t *testing.T) {
+		reader := NewReader(strings.NewReader(input), options)
+		var actual []string
+		for {
+			row, err := reader.Read()
+			if err == io.EOF {
+				break
+			}
+			should.So(t, err, should.BeNil)
+			actual = append(actual, render(row))
+		}
+		should.So(t, actual, should.Equal, expected)
+	})
+}
+func TestReader_Header(t *testing.T) {
+	reader := NewReader(strings.NewReader(example), Options{})
+	header, err := reader.Header()
+	should.So(t, err, should.BeNil)
+	should.So(t, header, should.Equal, []string{"name", "zip", "age", "admin", "note"})
+	row, err := reader.Read()
+	should.So(t, err, should.BeNil)
+	should.So(t, row.Keys(), should.Equal, header)
+}
+func TestReader_RaggedRows(t *testing.T) {
+	reader := NewReader(strings.NewReader("a,b\n1,2\n3\n"), Options{})
+	_, err := reader.Read()
+	should.So(t, err, should.BeNil)
+	_, err = reader.Read()
+	should.So(t, err, should.NOT.BeNil)
+	should.So(t, err.Error(), should.Equal, "record on line 3: wrong number of fields")
+}
+
+func render(value any) string {
+	out := &bytes.Buffer{}
+	parsing.Render(value, printing.NewCompactPrinter(out))
+	return out.String()
+}
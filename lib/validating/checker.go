@@ -0,0 +1,48 @@
+package validating
+
+import (
+	"slices"
+
+	"github.com/mdwhatcott/coding-challenges.fyi-json/lib/lexing"
+	"github.com/mdwhatcott/coding-challenges.fyi-json/lib/streaming"
+)
+
+// Checker applies a Profile to a stream of tokens.
+type Checker struct {
+	profile    Profile
+	offset     int
+	duplicates *DuplicateKeys
+	walker     *streaming.Walker
+	violations []Violation
+}
+
+func NewChecker(profile Profile) *Checker {
+	this := &Checker{profile: profile}
+	if profile.DuplicateKeys {
+		this.duplicates = NewDuplicateKeys()
+		this.walker = streaming.NewWalker(this.duplicates)
+	}
+	return this
+}
+
+func (this *Checker) Check(token lexing.Token) {
+	for _, rule := range this.profile.Rules {
+		if reason := rule(token); reason != "" {
+			this.violations = append(this.violations, Violation{Offset: this.offset, Reason: reason})
+		}
+	}
+	if this.walker != nil {
+		_ = this.walker.Walk(token)
+	}
+	this.offset += len(token.Value)
+}
+
+// Violations returns all violations found so far, ordered by offset.
+func (this *Checker) Violations() []Violation {
+	violations := slices.Clone(this.violations)
+	if this.duplicates != nil {
+		violations = append(violations, this.duplicates.Violations...)
+	}
+	slices.SortStableFunc(violations, func(a, b Violation) int { return a.Offset - b.Offset })
+	return violations
+}
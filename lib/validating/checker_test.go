@@ -0,0 +1,36 @@
+package validating
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/mdwhatcott/coding-challenges.fyi-json/lib/lexing"
+	"github.com/mdwhatcott/testing/should"
+)
+
+func TestChecker(t *testing.T) {
+	testChecker(t, "grammar", `{"a": 1e400, "a": "\uD834"}`)
+	testChecker(t, "rfc8259", "[\"\xff\", 1e400]",
+		Violation{Offset: 1, Reason: "string is not valid UTF-8"},
+	)
+	testChecker(t, "i-json", `{"a": 1e400, "a": "\uD834"}`,
+		Violation{Offset: 6, Reason: "number 1e400 is out of the range of an IEEE-754 double"},
+		Violation{Offset: 13, Reason: `duplicate key "a" (first defined at index 1)`},
+		Violation{Offset: 18, Reason: `unpaired high surrogate \uD834`},
+	)
+}
+func testChecker(t *testing.T, profileName, input string, expected ...Violation) {
+	t.Run(profileName+" "+input, func(t *testing.T) {
+		profile, ok := LookupProfile(profileName)
+		should.So(t, ok, should.BeTrue)
+		checker := NewChecker(profile)
+		for token := range lexing.Lex(strings.NewReader(input)) {
+			checker.Check(token)
+		}
+		should.So(t, checker.Violations(), should.Equal, expected)
+	})
+}
+func TestLookupUnknownProfile(t *testing.T) {
+	_, ok := LookupProfile("nope")
+	should.So(t, ok, should.BeFalse)
+}
@@ -0,0 +1,44 @@
+package validating
+
+import (
+	"fmt"
+
+	"github.com/mdwhatcott/coding-challenges.fyi-json/lib/streaming"
+)
+
+// DuplicateKeys is a streaming.Handler that records each object member
+// whose (decoded) name was already used by an earlier member of the same object.
+type DuplicateKeys struct {
+	streaming.NopHandler
+	offset     int
+	objects    []map[string]int
+	Violations []Violation
+}
+
+func NewDuplicateKeys() *DuplicateKeys {
+	return &DuplicateKeys{}
+}
+
+func (this *DuplicateKeys) Locate(offset int) {
+	this.offset = offset
+}
+func (this *DuplicateKeys) StartObject() error {
+	this.objects = append(this.objects, make(map[string]int))
+	return nil
+}
+func (this *DuplicateKeys) EndObject() error {
+	this.objects = this.objects[:len(this.objects)-1]
+	return nil
+}
+func (this *DuplicateKeys) Key(key string) error {
+	keys := this.objects[len(this.objects)-1]
+	if first, ok := keys[key]; ok {
+		this.Violations = append(this.Violations, Violation{
+			Offset: this.offset,
+			Reason: fmt.Sprintf("duplicate key %q (first defined at index %d)", key, first),
+		})
+	} else {
+		keys[key] = this.offset
+	}
+	return nil
+}
@@ -0,0 +1,32 @@
+package validating
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/mdwhatcott/coding-challenges.fyi-json/lib/streaming"
+	"github.com/mdwhatcott/testing/should"
+)
+
+func TestDuplicateKeys(t *testing.T) {
+	testDuplicateKeys(t, `{"a": 1, "b": 2}`)
+	testDuplicateKeys(t, `[{"a": 1}, {"a": 2}]`)
+	testDuplicateKeys(t, `{"a": {"a": {"a": 1}}}`)
+	testDuplicateKeys(t, `{"a": 1, "a": 2}`,
+		Violation{Offset: 9, Reason: `duplicate key "a" (first defined at index 1)`},
+	)
+	testDuplicateKeys(t, `{"a": 1, "a": 2, "b": {"c": 3, "c": 4}}`,
+		Violation{Offset: 9, Reason: `duplicate key "a" (first defined at index 1)`},
+		Violation{Offset: 31, Reason: `duplicate key "c" (first defined at index 23)`},
+	)
+	testDuplicateKeys(t, `{"a":1,"\u0061":2}`,
+		Violation{Offset: 7, Reason: `duplicate key "a" (first defined at index 1)`},
+	)
+}
+func testDuplicateKeys(t *testing.T, input string, expected ...Violation) {
+	t.Run(input, func(t *testing.T) {
+		handler := NewDuplicateKeys()
+		should.So(t, streaming.Walk(strings.NewReader(input), handler), should.BeNil)
+		should.So(t, handler.Violations, should.Equal, expected)
+	})
+}
@@ -0,0 +1,25 @@
+package validating
+
+// Profile names a set of rules which documents must follow beyond the JSON grammar.
+type Profile struct {
+	Name          string
+	DuplicateKeys bool // whether duplicate object keys are forbidden
+	Rules         []Rule
+}
+
+var (
+	Grammar = Profile{Name: "grammar"}
+	RFC8259 = Profile{Name: "rfc8259", Rules: []Rule{UTF8}}
+	IJSON   = Profile{Name: "i-json", DuplicateKeys: true, Rules: []Rule{UTF8, Surrogates, Noncharacters, Doubles}}
+)
+
+var Profiles = []Profile{Grammar, RFC8259, IJSON}
+
+func LookupProfile(name string) (Profile, bool) {
+	for _, profile := range Profiles {
+		if profile.Name == name {
+			return profile, true
+		}
+	}
+	return Profile{}, false
+}
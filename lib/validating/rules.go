@@ -0,0 +1,85 @@
+package validating
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"strconv"
+	"unicode/utf16"
+	"unicode/utf8"
+
+	"github.com/mdwhatcott/coding-challenges.fyi-json/lib/lexing"
+)
+
+// Rule inspects a single (legal) token and returns the reason it is
+// in violation, or the empty string.
+type Rule func(token lexing.Token) string
+
+func UTF8(token lexing.Token) string {
+	if token.Type == lexing.TokenString && !utf8.Valid(token.Value) {
+		return "string is not valid UTF-8"
+	}
+	return ""
+}
+
+func Doubles(token lexing.Token) string {
+	if token.Type != lexing.TokenNumber {
+		return ""
+	}
+	value, err := strconv.ParseFloat(string(token.Value), 64)
+	if err != nil {
+		return fmt.Sprintf("number %s is out of the range of an IEEE-754 double", token.Value)
+	}
+	if !bytes.ContainsAny(token.Value, ".eE") && math.Abs(value) > maxSafeInteger {
+		return fmt.Sprintf("integer %s cannot be represented exactly by an IEEE-754 double", token.Value)
+	}
+	return ""
+}
+
+const maxSafeInteger = 1<<53 - 1
+
+func Surrogates(token lexing.Token) string {
+	if token.Type != lexing.TokenString {
+		return ""
+	}
+	value := token.Value
+	for x := 0; x < len(value); x++ {
+		if value[x] != '\\' {
+			continue
+		}
+		x++
+		r, ok := escapedRune(value[x:])
+		if !ok || !utf16.IsSurrogate(r) {
+			continue
+		}
+		x += 4
+		if r >= 0xDC00 {
+			return fmt.Sprintf("unpaired low surrogate \\u%04X", r)
+		}
+		low, ok := escapedRune(value[min(x+2, len(value)):])
+		if !ok || value[x+1] != '\\' || low < 0xDC00 || low > 0xDFFF {
+			return fmt.Sprintf("unpaired high surrogate \\u%04X", r)
+		}
+		x += 6
+	}
+	return ""
+}
+func escapedRune(value []byte) (rune, bool) {
+	if len(value) < 5 || value[0] != 'u' {
+		return 0, false
+	}
+	n, err := strconv.ParseUint(string(value[1:5]), 16, 32)
+	return rune(n), err == nil
+}
+
+func Noncharacters(token lexing.Token) string {
+	if token.Type != lexing.TokenString {
+		return ""
+	}
+	for _, r := range lexing.Unquote(token.Value) {
+		if (0xFDD0 <= r && r <= 0xFDEF) || r&0xFFFE == 0xFFFE {
+			return fmt.Sprintf("string contains noncharacter U+%04X", r)
+		}
+	}
+	return ""
+}
@@ -0,0 +1,41 @@
+package validating
+
+import (
+	"testing"
+
+	"github.com/mdwhatcott/coding-challenges.fyi-json/lib/lexing"
+	"github.com/mdwhatcott/testing/should"
+)
+
+func TestUTF8(t *testing.T) {
+	testRule(t, UTF8, lexing.TokenString, `"héllo"`, "")
+	testRule(t, UTF8, lexing.TokenString, "\"\xff\"", "string is not valid UTF-8")
+}
+func TestDoubles(t *testing.T) {
+	testRule(t, Doubles, lexing.TokenNumber, `9007199254740991`, "")
+	testRule(t, Doubles, lexing.TokenNumber, `-9007199254740991`, "")
+	testRule(t, Doubles, lexing.TokenNumber, `1.5e308`, "")
+	testRule(t, Doubles, lexing.TokenNumber, `9007199254740993`,
+		"integer 9007199254740993 cannot be represented exactly by an IEEE-754 double")
+	testRule(t, Doubles, lexing.TokenNumber, `1e309`,
+		"number 1e309 is out of the range of an IEEE-754 double")
+}
+func TestSurrogates(t *testing.T) {
+	testRule(t, Surrogates, lexing.TokenString, `"\uD834\uDD1E"`, "")
+	testRule(t, Surrogates, lexing.TokenString, `"\\uD834"`, "")
+	testRule(t, Surrogates, lexing.TokenString, `"A\uD834"`, `unpaired high surrogate \uD834`)
+	testRule(t, Surrogates, lexing.TokenString, `"\uD834A"`, `unpaired high surrogate \uD834`)
+	testRule(t, Surrogates, lexing.TokenString, `"\uD834\n"`, `unpaired high surrogate \uD834`)
+	testRule(t, Surrogates, lexing.TokenString, `"\uDD1E"`, `unpaired low surrogate \uDD1E`)
+}
+func TestNoncharacters(t *testing.T) {
+	testRule(t, Noncharacters, lexing.TokenString, "\"\uFFFD\"", "")
+	testRule(t, Noncharacters, lexing.TokenString, `"\uFDD0"`, "string contains noncharacter U+FDD0")
+	testRule(t, Noncharacters, lexing.TokenString, "\"a\uFFFF\"", "string contains noncharacter U+FFFF")
+	testRule(t, Noncharacters, lexing.TokenString, "\"\U0001FFFE\"", "string contains noncharacter U+1FFFE")
+}
+func testRule(t *testing.T, rule Rule, tokenType lexing.TokenType, value, expected string) {
+	t.Run(value, func(t *testing.T) {
+		should.So(t, rule(lexing.Token{Type: tokenType, Value: []byte(value)}), should.Equal, expected)
+	})
+}
@@ -0,0 +1,13 @@
+package validating
+
+import "fmt"
+
+// Violation describes a rule broken by an otherwise well-formed document.
+type Violation struct {
+	Offset int
+	Reason string
+}
+
+func (this Violation) String() string {
+	return fmt.Sprintf("at index %d: %s", this.Offset, this.Reason)
+}